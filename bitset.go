@@ -0,0 +1,204 @@
+package set
+
+import (
+	"errors"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// ErrSizeMismatch is returned when two bit sets, or a bit set and a universe,
+// do not have the same size.
+var ErrSizeMismatch = errors.New("set: the sizes of the bit sets do not match")
+
+// BitSet is a set of the integers from 0 to n-1, for a fixed n, stored as one
+// bit per integer. Together with a Universe, it stores sets of any n values
+// compactly, and operates on them a word at a time.
+type BitSet struct {
+	words []uint64
+	n     int
+}
+
+// NewBitSet allocates memory for a new, empty BitSet over the integers from 0
+// to n-1. It panics if n is negative.
+func NewBitSet(n int) (b BitSet) {
+	if n < 0 {
+		panic("set: the size of a BitSet cannot be negative")
+	}
+
+	b.words = make([]uint64, (n+63)/64)
+	b.n = n
+
+	return b
+}
+
+// Size returns n, the number of integers the bit set b ranges over.
+func (b *BitSet) Size() int {
+	return b.n
+}
+
+// Add adds i to the bit set b. If it already exists in the set or it is out of
+// the range of the set, no addition is performed and false is returned.
+// Otherwise, it returns true.
+func (b *BitSet) Add(i int) bool {
+	if i < 0 || i >= b.n || b.Has(i) {
+		return false
+	}
+
+	b.words[i/64] |= 1 << (uint(i) % 64)
+
+	return true
+}
+
+// Remove removes i from the bit set b. It returns false if i does not exist in
+// the set.
+func (b *BitSet) Remove(i int) bool {
+	if !b.Has(i) {
+		return false
+	}
+
+	b.words[i/64] &^= 1 << (uint(i) % 64)
+
+	return true
+}
+
+// Has returns true if i exists in the bit set, otherwise false.
+func (b *BitSet) Has(i int) bool {
+	if i < 0 || i >= b.n {
+		return false
+	}
+
+	return b.words[i/64]&(1<<(uint(i)%64)) != 0
+}
+
+// PopCount returns the number of elements in the bit set b.
+func (b *BitSet) PopCount() int {
+	n := 0
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+
+	return n
+}
+
+// Empty returns true if the bit set is empty, otherwise false.
+func (b *BitSet) Empty() bool {
+	for _, w := range b.words {
+		if w != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NextSet returns the smallest element of the bit set b that is greater than
+// or equal to i and true, or false if there is no such element. It allows to
+// iterate over the set:
+//
+//	for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+//		...
+//	}
+func (b *BitSet) NextSet(i int) (int, bool) {
+	if i < 0 {
+		i = 0
+	}
+	if i >= b.n {
+		return 0, false
+	}
+
+	w := i / 64
+	word := b.words[w] >> (uint(i) % 64)
+	if word != 0 {
+		return i + bits.TrailingZeros64(word), true
+	}
+
+	for w++; w < len(b.words); w++ {
+		if b.words[w] != 0 {
+			return w*64 + bits.TrailingZeros64(b.words[w]), true
+		}
+	}
+
+	return 0, false
+}
+
+// Equal returns true if the two bit sets have the same size and the very same
+// elements, otherwise false.
+func (b1 *BitSet) Equal(b2 BitSet) bool {
+	if b1.n != b2.n {
+		return false
+	}
+
+	for i, w := range b1.words {
+		if w != b2.words[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// combine returns the bit set with the words op(w1, w2) of the two bit sets.
+func (b1 *BitSet) combine(b2 BitSet, op func(w1, w2 uint64) uint64) (BitSet, error) {
+	if b1.n != b2.n {
+		return BitSet{}, ErrSizeMismatch
+	}
+
+	b := NewBitSet(b1.n)
+	for i := range b.words {
+		b.words[i] = op(b1.words[i], b2.words[i])
+	}
+
+	return b, nil
+}
+
+// Union returns the union of the two bit sets. They must have the same size,
+// otherwise ErrSizeMismatch is returned.
+func (b1 *BitSet) Union(b2 BitSet) (BitSet, error) {
+	return b1.combine(b2, func(w1, w2 uint64) uint64 { return w1 | w2 })
+}
+
+// Intersection returns the intersection of the two bit sets. They must have
+// the same size, otherwise ErrSizeMismatch is returned.
+func (b1 *BitSet) Intersection(b2 BitSet) (BitSet, error) {
+	return b1.combine(b2, func(w1, w2 uint64) uint64 { return w1 & w2 })
+}
+
+// Difference returns the difference b1\b2. The bit sets must have the same
+// size, otherwise ErrSizeMismatch is returned.
+func (b1 *BitSet) Difference(b2 BitSet) (BitSet, error) {
+	return b1.combine(b2, func(w1, w2 uint64) uint64 { return w1 &^ w2 })
+}
+
+// Complement returns the bit set with the integers from 0 to n-1 that are not
+// in the bit set b.
+func (b *BitSet) Complement() BitSet {
+	c := NewBitSet(b.n)
+	for i, w := range b.words {
+		c.words[i] = ^w
+	}
+
+	// Clear the bits past n in the last word.
+	if r := uint(b.n) % 64; r != 0 {
+		c.words[len(c.words)-1] &= 1<<r - 1
+	}
+
+	return c
+}
+
+// String returns the elements of the bit set b in increasing order, in the
+// form {0, 3, 5}.
+func (b BitSet) String() string {
+	var sb strings.Builder
+
+	sb.WriteByte('{')
+	for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+		if sb.Len() > 1 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(strconv.Itoa(i))
+	}
+	sb.WriteByte('}')
+
+	return sb.String()
+}
@@ -0,0 +1,102 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestBitSet(t *testing.T) {
+	b := NewBitSet(70)
+
+	if !b.Add(0) || !b.Add(3) || !b.Add(69) {
+		t.Errorf("Could not add elements in the bit set %v", b)
+	}
+	if b.Add(3) || b.Add(70) || b.Add(-1) {
+		t.Errorf("An invalid element was added in the bit set %v", b)
+	}
+
+	if b.PopCount() != 3 || !b.Has(69) || b.Has(68) {
+		t.Errorf("The bit set %v does not have the right elements.", b)
+	}
+
+	if got, want := b.String(), "{0, 3, 69}"; got != want {
+		t.Errorf("The bit set is %v, instead of %v.", got, want)
+	}
+
+	if i, ok := b.NextSet(4); !ok || i != 69 {
+		t.Errorf("The next element after 4 is %d, instead of 69.", i)
+	}
+
+	if !b.Remove(69) || b.Remove(69) {
+		t.Errorf("Could not remove 69 from the bit set %v", b)
+	}
+}
+
+func TestBitSetAlgebra(t *testing.T) {
+	b1, b2 := NewBitSet(66), NewBitSet(66)
+	b1.Add(1)
+	b1.Add(65)
+	b2.Add(1)
+	b2.Add(2)
+
+	tests := []struct {
+		name string
+		op   func(BitSet) (BitSet, error)
+		want string
+	}{
+		{"union", b1.Union, "{1, 2, 65}"},
+		{"intersection", b1.Intersection, "{1}"},
+		{"difference", b1.Difference, "{65}"},
+	}
+
+	for _, test := range tests {
+		got, err := test.op(b2)
+		if err != nil || got.String() != test.want {
+			t.Errorf("The %s of %v and %v resulted in %v, instead of %v.", test.name, b1, b2, got, test.want)
+		}
+	}
+
+	c := b1.Complement()
+	if c.PopCount() != 64 || c.Has(1) || c.Has(65) || !c.Has(64) {
+		t.Errorf("The complement of %v is %v.", b1, c)
+	}
+
+	if _, err := b1.Union(NewBitSet(3)); err != ErrSizeMismatch {
+		t.Errorf("The union of bit sets of different sizes did not fail.")
+	}
+}
+
+func TestUniverse(t *testing.T) {
+	type weekday string
+	u, err := NewUniverse(weekday("mon"), weekday("tue"), weekday("wed"), weekday("mon"))
+	if err != nil || u.Size() != 3 {
+		t.Fatalf("The universe has %d values.\n%v", u.Size(), err)
+	}
+
+	s := CreateSet(weekday("mon"))
+	s.Add(weekday("wed"))
+
+	b, err := u.BitSet(s)
+	if err != nil || b.String() != "{0, 2}" {
+		t.Errorf("Converting %v resulted in %v.\n%v", s, b, err)
+	}
+
+	back, err := u.FromBitSet(b.Complement())
+	if err != nil || !back.Equal(CreateSet(weekday("tue"))) || !back.SameType(s) {
+		t.Errorf("Converting the complement of %v resulted in %v.", b, back)
+	}
+
+	if _, err := u.BitSet(CreateSet("mon")); err == nil {
+		t.Errorf("Converting a set of strings succeeded.")
+	}
+
+	s.Add(weekday("sun"))
+	if _, err := u.BitSet(s); err == nil {
+		t.Errorf("Converting a set with an element outside the universe succeeded.")
+	} else if _, ok := err.(*DomainError); !ok {
+		t.Errorf("Converting a set with an element outside the universe failed with %v.", err)
+	}
+
+	if _, err := NewUniverse(1, "a"); err == nil {
+		t.Errorf("Creating a universe of mixed types succeeded.")
+	}
+}
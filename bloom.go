@@ -0,0 +1,252 @@
+package set
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"reflect"
+)
+
+// ErrIncompatibleFilters is returned when two probabilistic filters cannot be
+// combined, because they were created with different parameters.
+var ErrIncompatibleFilters = errors.New("set: the filters are not compatible")
+
+// filterIndexes returns the two independent hashes of elem from which the
+// probabilistic filters derive all of their positions, by double hashing.
+func filterIndexes(elem interface{}) (h1, h2 uint64) {
+	h := hashElement(elem)
+
+	// An even h2 would only ever reach half of the positions of a filter of
+	// even size.
+	return h.Hi, h.Lo | 1
+}
+
+// BloomFilter is a probabilistic set: Has never misses an element that was
+// added, but may report elements that were not, with a false-positive rate
+// chosen when the filter is created. It stores about 10 bits per element for a
+// rate of 1%, regardless of the size of the elements. Elements cannot be
+// removed.
+type BloomFilter struct {
+	bits         BitSet
+	k            int
+	n            int
+	elementsType reflect.Type
+}
+
+// NewBloomFilter allocates memory for a new, empty BloomFilter that holds n
+// elements with a false-positive rate of at most p. It panics if n is not
+// positive or p is not in (0, 1).
+func NewBloomFilter(n int, p float64) (b BloomFilter) {
+	if n <= 0 {
+		panic("set: a BloomFilter must hold at least one element")
+	}
+	if !(p > 0 && p < 1) {
+		panic("set: the false-positive rate of a BloomFilter must be in (0, 1)")
+	}
+
+	m := math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2))
+	b.bits = NewBitSet(int(m))
+	b.k = int(math.Max(1, math.Round(m/float64(n)*math.Ln2)))
+
+	return b
+}
+
+// BloomFilterOf returns a BloomFilter with the elements and the type of the set
+// s, sized for them with a false-positive rate of p. It panics if p is not in
+// (0, 1).
+func BloomFilterOf(s Set, p float64) BloomFilter {
+	n := s.Length()
+	if n == 0 {
+		n = 1
+	}
+
+	b := NewBloomFilter(n, p)
+	b.elementsType = s.elementsType
+
+	for elem := range s.Set {
+		b.Add(elem)
+	}
+
+	return b
+}
+
+// SetType sets the type of the elements the filter accepts, with the same rules
+// as Set.SetType.
+func (b *BloomFilter) SetType(elem interface{}) error {
+	newType := reflect.ValueOf(elem).Type()
+
+	if b.elementsType == nil {
+		b.elementsType = newType
+		return nil
+	}
+
+	return &TypeError{b.elementsType, newType, "Trying to re-set the set's type."}
+}
+
+// ElementsType returns the type of the elements the filter accepts, or nil if
+// it accepts elements of any type.
+func (b *BloomFilter) ElementsType() reflect.Type {
+	return b.elementsType
+}
+
+func (b *BloomFilter) properType(elem interface{}) bool {
+	return b.elementsType == nil || reflect.ValueOf(elem).Type() == b.elementsType
+}
+
+// Add adds elem to the filter b. If the element is not of the correct type, or
+// if the filter reports it as already present, which may be a false positive,
+// nothing changes and false is returned. Otherwise it returns true.
+func (b *BloomFilter) Add(elem interface{}) bool {
+	if !b.properType(elem) {
+		return false
+	}
+
+	h1, h2 := filterIndexes(elem)
+	m := uint64(b.bits.Size())
+
+	added := false
+	for i := 0; i < b.k; i++ {
+		if b.bits.Add(int((h1 + uint64(i)*h2) % m)) {
+			added = true
+		}
+	}
+
+	if added {
+		b.n++
+	}
+
+	return added
+}
+
+// Has returns true if elem was probably added to the filter b, and false if it
+// certainly was not.
+func (b *BloomFilter) Has(elem interface{}) bool {
+	if !b.properType(elem) || b.bits.Size() == 0 {
+		return false
+	}
+
+	h1, h2 := filterIndexes(elem)
+	m := uint64(b.bits.Size())
+
+	for i := 0; i < b.k; i++ {
+		if !b.bits.Has(int((h1 + uint64(i)*h2) % m)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Length returns the number of times Add returned true, which may be a little
+// less than the number of distinct elements added. After a Union, it is an
+// estimate from the number of bits set.
+func (b *BloomFilter) Length() int {
+	return b.n
+}
+
+// Empty returns true if no element was added to the filter b.
+func (b *BloomFilter) Empty() bool {
+	return b.bits.Empty()
+}
+
+// FalsePositiveRate estimates the probability that Has reports an element that
+// was not added, given the bits set so far.
+func (b *BloomFilter) FalsePositiveRate() float64 {
+	if b.bits.Size() == 0 {
+		return 0
+	}
+
+	return math.Pow(float64(b.bits.PopCount())/float64(b.bits.Size()), float64(b.k))
+}
+
+// Union returns a filter that reports every element that b1 or b2 reports. The
+// filters must have been created with the same size and false-positive rate,
+// otherwise ErrIncompatibleFilters is returned, and with the same type,
+// otherwise a TypeError is returned.
+func (b1 *BloomFilter) Union(b2 BloomFilter) (BloomFilter, error) {
+	if b1.k != b2.k || b1.bits.Size() != b2.bits.Size() {
+		return BloomFilter{}, ErrIncompatibleFilters
+	}
+
+	if b1.elementsType != b2.elementsType {
+		return BloomFilter{}, &TypeError{b1.elementsType, b2.elementsType,
+			"The filters' types do not match."}
+	}
+
+	bits, err := b1.bits.Union(b2.bits)
+	if err != nil {
+		return BloomFilter{}, err
+	}
+
+	// Estimate the number of elements from the fraction of bits set.
+	n := b1.n + b2.n
+	if m, x := float64(bits.Size()), float64(bits.PopCount()); x < m {
+		n = int(math.Round(-m / float64(b1.k) * math.Log1p(-x/m)))
+	}
+
+	return BloomFilter{bits: bits, k: b1.k, n: n, elementsType: b1.elementsType}, nil
+}
+
+// The binary format of a BloomFilter, all integers little-endian:
+//
+//	magic    "BLMF"
+//	k        uint32, the number of hash functions
+//	n        uint64, the value of Length
+//	m        uint64, the number of bits
+//	payload  (m+63)/64 uint64s, the bits
+//
+// The type of the filter is not included.
+const bloomMagic = "BLMF"
+
+var errBloomFormat = errors.New("set: invalid BloomFilter encoding")
+
+// MarshalBinary encodes the filter b in a portable binary format.
+func (b BloomFilter) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, 24+8*len(b.bits.words))
+	buf = append(buf, bloomMagic...)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(b.k))
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(b.n))
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(b.bits.Size()))
+
+	for _, w := range b.bits.words {
+		buf = binary.LittleEndian.AppendUint64(buf, w)
+	}
+
+	return buf, nil
+}
+
+// UnmarshalBinary decodes a filter that was encoded with MarshalBinary. The
+// filter keeps its type, if it has one.
+func (b *BloomFilter) UnmarshalBinary(data []byte) error {
+	if len(data) < 24 || string(data[:4]) != bloomMagic {
+		return errBloomFormat
+	}
+
+	k := int(binary.LittleEndian.Uint32(data[4:]))
+	n := int(binary.LittleEndian.Uint64(data[8:]))
+	m := binary.LittleEndian.Uint64(data[16:])
+	data = data[24:]
+
+	words := m / 64
+	if m%64 != 0 {
+		words++
+	}
+
+	if k == 0 || m == 0 || uint64(len(data)) != 8*words {
+		return errBloomFormat
+	}
+
+	bits := NewBitSet(int(m))
+	for i := range bits.words {
+		bits.words[i] = binary.LittleEndian.Uint64(data[8*i:])
+	}
+
+	// Bits past the end of the set must be zero.
+	if r := m % 64; r != 0 && bits.words[len(bits.words)-1]>>r != 0 {
+		return errBloomFormat
+	}
+
+	b.bits, b.k, b.n = bits, k, n
+
+	return nil
+}
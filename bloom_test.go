@@ -0,0 +1,136 @@
+package set
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+// falsePositiveRate returns the fraction of the integers from n to n+trials-1
+// for which has returns true.
+func falsePositiveRate(has func(elem interface{}) bool, n, trials int) float64 {
+	fp := 0
+	for i := n; i < n+trials; i++ {
+		if has(i) {
+			fp++
+		}
+	}
+
+	return float64(fp) / float64(trials)
+}
+
+func TestBloomFilter(t *testing.T) {
+	const n = 10000
+
+	for _, p := range []float64{0.1, 0.01, 0.001} {
+		b := NewBloomFilter(n, p)
+		for i := 0; i < n; i++ {
+			b.Add(i)
+		}
+
+		for i := 0; i < n; i++ {
+			if !b.Has(i) {
+				t.Fatalf("The element %d was added to the filter, but is missing.", i)
+			}
+		}
+
+		if rate := falsePositiveRate(b.Has, n, 100000); rate > 1.5*p {
+			t.Errorf("The false-positive rate of a filter for %v is %v.", p, rate)
+		}
+
+		if est := b.FalsePositiveRate(); est > 1.5*p {
+			t.Errorf("The estimated false-positive rate of a filter for %v is %v.", p, est)
+		}
+
+		// Elements that are false positives when they are added are not
+		// counted.
+		if float64(b.Length()) < n*(1-p) || b.Length() > n {
+			t.Errorf("The filter has %d elements, instead of about %d.", b.Length(), n)
+		}
+	}
+}
+
+func TestBloomFilterOf(t *testing.T) {
+	s := CreateSet("a")
+	s.AddAll("b", "c")
+
+	b := BloomFilterOf(s, 0.01)
+	if !b.Has("a") || !b.Has("c") || b.Has(1) || b.ElementsType() != s.ElementsType() {
+		t.Errorf("The filter of %v has the wrong elements or type.", s)
+	}
+
+	if b.Add(1) || b.Add("a") || !b.Add("d") {
+		t.Errorf("The filter of %v accepted the wrong elements.", s)
+	}
+
+	empty := BloomFilterOf(NewSet(), 0.01)
+	if !empty.Empty() || empty.Has("a") {
+		t.Errorf("The filter of the empty set is not empty.")
+	}
+}
+
+func TestBloomFilterUnion(t *testing.T) {
+	b1, b2 := NewBloomFilter(1000, 0.01), NewBloomFilter(1000, 0.01)
+	for i := 0; i < 500; i++ {
+		b1.Add(i)
+		b2.Add(i + 500)
+	}
+
+	b, err := b1.Union(b2)
+	if err != nil {
+		t.Fatalf("The union of the filters returned %v.", err)
+	}
+
+	for i := 0; i < 1000; i++ {
+		if !b.Has(i) {
+			t.Fatalf("The element %d is missing from the union.", i)
+		}
+	}
+
+	if b.Length() < 950 || b.Length() > 1050 {
+		t.Errorf("The union has about %d elements, instead of 1000.", b.Length())
+	}
+
+	other := NewBloomFilter(1000, 0.1)
+	if _, err := b1.Union(other); err != ErrIncompatibleFilters {
+		t.Errorf("The union of incompatible filters returned %v.", err)
+	}
+
+	typed := NewBloomFilter(1000, 0.01)
+	typed.SetType(0)
+	if _, err := b1.Union(typed); err == nil {
+		t.Errorf("The union of filters of different types returned no error.")
+	}
+}
+
+func TestBloomFilterBinary(t *testing.T) {
+	b := NewBloomFilter(100, 0.01)
+	for i := 0; i < 100; i++ {
+		b.Add(i)
+	}
+
+	data, _ := b.MarshalBinary()
+
+	var decoded BloomFilter
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Decoding the filter returned %v.", err)
+	}
+
+	for i := 0; i < 100; i++ {
+		if !decoded.Has(i) {
+			t.Fatalf("The element %d is missing from the decoded filter.", i)
+		}
+	}
+
+	if decoded.Length() != b.Length() || falsePositiveRate(decoded.Has, 100, 1000) != falsePositiveRate(b.Has, 100, 1000) {
+		t.Errorf("The decoded filter differs from the original.")
+	}
+
+	empty := append([]byte(nil), data[:24]...)
+	binary.LittleEndian.PutUint64(empty[16:], 0)
+
+	for _, bad := range [][]byte{nil, data[:len(data)-8], append([]byte("XXXX"), data[4:]...), empty} {
+		if err := decoded.UnmarshalBinary(bad); err == nil {
+			t.Errorf("Decoding %d bytes returned no error.", len(bad))
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package set
+
+import (
+	"container/list"
+	"math/rand"
+	"reflect"
+)
+
+// EvictionPolicy decides which element a BoundedSet evicts when it is full. The
+// BoundedSet reports to it every element that is added, touched (found again by
+// Has or Add) and removed, and asks it for a victim when it needs room.
+type EvictionPolicy interface {
+	Added(elem interface{})
+	Touched(elem interface{})
+	Removed(elem interface{})
+	// Victim forgets and returns the element that should be evicted next.
+	Victim() interface{}
+}
+
+// listPolicy keeps the elements in a list, from the next victim to the last.
+// Touching an element moves it to the end of the list, if touch is set.
+type listPolicy struct {
+	order *list.List
+	index map[interface{}]*list.Element
+	touch bool
+}
+
+// NewLRU returns an EvictionPolicy that evicts the least recently used element.
+// Both Add and Has count as a use.
+func NewLRU() EvictionPolicy {
+	return &listPolicy{list.New(), make(map[interface{}]*list.Element), true}
+}
+
+// NewFIFO returns an EvictionPolicy that evicts the element that was added
+// first.
+func NewFIFO() EvictionPolicy {
+	return &listPolicy{list.New(), make(map[interface{}]*list.Element), false}
+}
+
+func (p *listPolicy) Added(elem interface{}) {
+	p.index[elem] = p.order.PushBack(elem)
+}
+
+func (p *listPolicy) Touched(elem interface{}) {
+	if e, ok := p.index[elem]; ok && p.touch {
+		p.order.MoveToBack(e)
+	}
+}
+
+func (p *listPolicy) Removed(elem interface{}) {
+	if e, ok := p.index[elem]; ok {
+		p.order.Remove(e)
+		delete(p.index, elem)
+	}
+}
+
+func (p *listPolicy) Victim() interface{} {
+	elem := p.order.Front().Value
+	p.Removed(elem)
+
+	return elem
+}
+
+// randomPolicy keeps the elements in a slice, so that it can pick one of them
+// uniformly at random.
+type randomPolicy struct {
+	elems []interface{}
+	index map[interface{}]int
+	rand  *rand.Rand
+}
+
+// NewRandom returns an EvictionPolicy that evicts an element at random. The
+// choices are determined by seed.
+func NewRandom(seed int64) EvictionPolicy {
+	return &randomPolicy{index: make(map[interface{}]int), rand: rand.New(rand.NewSource(seed))}
+}
+
+func (p *randomPolicy) Added(elem interface{}) {
+	p.index[elem] = len(p.elems)
+	p.elems = append(p.elems, elem)
+}
+
+func (p *randomPolicy) Touched(elem interface{}) {}
+
+func (p *randomPolicy) Removed(elem interface{}) {
+	i, ok := p.index[elem]
+	if !ok {
+		return
+	}
+
+	last := p.elems[len(p.elems)-1]
+	p.elems[i] = last
+	p.index[last] = i
+	p.elems = p.elems[:len(p.elems)-1]
+	delete(p.index, elem)
+}
+
+func (p *randomPolicy) Victim() interface{} {
+	elem := p.elems[p.rand.Intn(len(p.elems))]
+	p.Removed(elem)
+
+	return elem
+}
+
+// BoundedStats holds the counters of a BoundedSet. Hits and Misses count the
+// calls to Has that found and did not find their element, respectively.
+type BoundedStats struct {
+	Hits, Misses, Evictions uint64
+}
+
+// BoundedSet is a set that holds at most a fixed number of elements. When it is
+// full, adding a new element evicts one of the existing elements, which one
+// being decided by its EvictionPolicy.
+type BoundedSet struct {
+	set      Set
+	capacity int
+	policy   EvictionPolicy
+	onEvict  func(elem interface{})
+	stats    BoundedStats
+}
+
+// NewBoundedSet allocates memory for a new BoundedSet that holds at most
+// capacity elements and evicts them according to policy. It panics if capacity
+// is not positive. A BoundedSet created this way can have elements of varying
+// types.
+func NewBoundedSet(capacity int, policy EvictionPolicy) (s BoundedSet) {
+	if capacity <= 0 {
+		panic("set: the capacity of a BoundedSet must be positive")
+	}
+
+	s.set = NewSet()
+	s.capacity = capacity
+	s.policy = policy
+
+	return s
+}
+
+// SetType sets the type of the elements the set accepts, with the same rules as
+// Set.SetType.
+func (s *BoundedSet) SetType(elem interface{}) error {
+	return s.set.SetType(elem)
+}
+
+// OnEvict registers fn to be called with every element the set evicts. Only
+// evictions are reported, not calls to Remove.
+func (s *BoundedSet) OnEvict(fn func(elem interface{})) {
+	s.onEvict = fn
+}
+
+// Add adds elem to the set s, evicting an element if the set is full. As with
+// Set.Add, if the element exists in the set or if it is not of the correct
+// type, no addition is performed and false is returned; an existing element
+// is still touched. Otherwise, it returns true.
+func (s *BoundedSet) Add(elem interface{}) bool {
+	if !s.set.properType(elem) {
+		return false
+	}
+
+	if _, ok := s.set.Set[elem]; ok {
+		s.policy.Touched(elem)
+		return false
+	}
+
+	if s.set.Length() >= s.capacity {
+		victim := s.policy.Victim()
+		s.set.Remove(victim)
+		s.stats.Evictions++
+
+		if s.onEvict != nil {
+			s.onEvict(victim)
+		}
+	}
+
+	s.set.Add(elem)
+	s.policy.Added(elem)
+
+	return true
+}
+
+// Has returns true if the element provided exists in the set, otherwise false.
+// It updates the hit and miss counters and touches the element.
+func (s *BoundedSet) Has(elem interface{}) bool {
+	if !s.set.Has(elem) {
+		s.stats.Misses++
+		return false
+	}
+
+	s.stats.Hits++
+	s.policy.Touched(elem)
+
+	return true
+}
+
+// Remove removes elem from the set s, as Set.Remove does.
+func (s *BoundedSet) Remove(elem interface{}) bool {
+	if !s.set.Remove(elem) {
+		return false
+	}
+
+	s.policy.Removed(elem)
+
+	return true
+}
+
+// Length returns the number of elements in the set s.
+func (s *BoundedSet) Length() int {
+	return s.set.Length()
+}
+
+// Empty returns true if the set is empty, otherwise false.
+func (s *BoundedSet) Empty() bool {
+	return s.set.Empty()
+}
+
+// ElementsType returns the type of the elements the set accepts, or nil if it
+// accepts elements of any type.
+func (s *BoundedSet) ElementsType() reflect.Type {
+	return s.set.elementsType
+}
+
+// Each calls fn for every element of the set s, in no particular order, until
+// fn returns false. It does not touch the elements.
+func (s *BoundedSet) Each(fn func(elem interface{}) bool) {
+	s.set.Each(fn)
+}
+
+// Capacity returns the maximum number of elements the set s can hold.
+func (s *BoundedSet) Capacity() int {
+	return s.capacity
+}
+
+// Stats returns the hit, miss and eviction counters of the set s.
+func (s *BoundedSet) Stats() BoundedStats {
+	return s.stats
+}
@@ -0,0 +1,69 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestBoundedSetLRU(t *testing.T) {
+	s := NewBoundedSet(2, NewLRU())
+	var evicted []interface{}
+	s.OnEvict(func(elem interface{}) { evicted = append(evicted, elem) })
+
+	s.Add(1)
+	s.Add(2)
+	s.Has(1)
+
+	if !s.Add(3) {
+		t.Errorf("3 was not added in the set %v", s)
+	}
+
+	if s.Has(2) || !s.Has(1) || !s.Has(3) {
+		t.Errorf("The least recently used element was not evicted from the set %v", s)
+	}
+
+	if len(evicted) != 1 || evicted[0] != 2 {
+		t.Errorf("The evicted elements are %v, instead of [2].", evicted)
+	}
+
+	want := BoundedStats{Hits: 3, Misses: 1, Evictions: 1}
+	if s.Stats() != want {
+		t.Errorf("The stats of the set %v are %v, instead of %v.", s, s.Stats(), want)
+	}
+}
+
+func TestBoundedSetFIFO(t *testing.T) {
+	s := NewBoundedSet(2, NewFIFO())
+
+	s.Add(1)
+	s.Add(2)
+	s.Has(1)
+	s.Add(1)
+	s.Add(3)
+
+	if s.Has(1) || !s.Has(2) || !s.Has(3) {
+		t.Errorf("The first element was not evicted from the set %v", s)
+	}
+
+	s.Remove(2)
+	s.Add(4)
+	if s.Length() != 2 || s.Stats().Evictions != 1 {
+		t.Errorf("The set %v evicted an element although it had room.", s)
+	}
+}
+
+func TestBoundedSetRandom(t *testing.T) {
+	s := NewBoundedSet(3, NewRandom(1))
+	s.SetType(1)
+
+	for i := 0; i < 100; i++ {
+		s.Add(i)
+	}
+
+	if s.Length() != 3 || s.Stats().Evictions != 97 {
+		t.Errorf("The set %v has %d elements after %d evictions.", s, s.Length(), s.Stats().Evictions)
+	}
+
+	if s.Add("a") {
+		t.Errorf("\"a\" was added in the set %v", s)
+	}
+}
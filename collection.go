@@ -0,0 +1,124 @@
+package set
+
+import (
+	"reflect"
+)
+
+// Container is implemented by sets that can tell whether they hold an element
+// and how many elements they hold.
+type Container interface {
+	Has(elem interface{}) bool
+	Length() int
+	Empty() bool
+}
+
+// Iterable is implemented by sets that can enumerate their elements. Each
+// calls fn for every element, once, until fn returns false.
+type Iterable interface {
+	Each(fn func(elem interface{}) bool)
+}
+
+// Collection is implemented by every finite set of this package that holds
+// arbitrary elements: *Set, *OrderedSet, *SortedSet, *ExpiringSet,
+// *BoundedSet, the lazy views and the read-only views. ElementsType returns
+// the type of the elements the set accepts, or nil if it accepts any type.
+//
+// The functions Union, Intersection, Difference, Equal and Subset operate on
+// any two Collections, even of different implementations, with the same type
+// rules as the methods of Set.
+type Collection interface {
+	Container
+	Iterable
+	ElementsType() reflect.Type
+}
+
+// Union returns the union of the two collections as a Set with the type of c1.
+// As with Set.Union, unless either collection is empty, both must have the
+// same type, otherwise a TypeError is returned.
+func Union(c1, c2 Collection) (Set, error) {
+	if !c1.Empty() && !c2.Empty() && c1.ElementsType() != c2.ElementsType() {
+		return Set{}, &TypeError{c1.ElementsType(), c2.ElementsType(),
+			"The sets' types do not match."}
+	}
+
+	s := NewSet()
+	s.elementsType = c1.ElementsType()
+
+	for _, c := range []Collection{c1, c2} {
+		c.Each(func(elem interface{}) bool {
+			s.Add(elem)
+			return true
+		})
+	}
+
+	return s, nil
+}
+
+// Intersection returns the intersection of the two collections as a Set with
+// the type of c1. The type rules are those of Union.
+func Intersection(c1, c2 Collection) (Set, error) {
+	if !c1.Empty() && !c2.Empty() && c1.ElementsType() != c2.ElementsType() {
+		return Set{}, &TypeError{c1.ElementsType(), c2.ElementsType(),
+			"The sets' types do not match."}
+	}
+
+	s := NewSet()
+	s.elementsType = c1.ElementsType()
+
+	// Walk the smaller collection.
+	small, large := c1, c2
+	if small.Length() > large.Length() {
+		small, large = large, small
+	}
+
+	small.Each(func(elem interface{}) bool {
+		if large.Has(elem) {
+			s.Add(elem)
+		}
+		return true
+	})
+
+	return s, nil
+}
+
+// Difference returns the difference c1\c2 as a Set with the type of c1. As
+// with Set.Difference, both collections must have the same type, otherwise a
+// TypeError is returned.
+func Difference(c1, c2 Collection) (Set, error) {
+	if c1.ElementsType() != c2.ElementsType() {
+		return Set{}, &TypeError{c1.ElementsType(), c2.ElementsType(),
+			"The sets' type do not match."}
+	}
+
+	s := NewSet()
+	s.elementsType = c1.ElementsType()
+
+	c1.Each(func(elem interface{}) bool {
+		if !c2.Has(elem) {
+			s.Add(elem)
+		}
+		return true
+	})
+
+	return s, nil
+}
+
+// Subset returns true if every element of c1 is also an element of c2.
+func Subset(c1, c2 Collection) bool {
+	if c1.Length() > c2.Length() {
+		return false
+	}
+
+	subset := true
+	c1.Each(func(elem interface{}) bool {
+		subset = c2.Has(elem)
+		return subset
+	})
+
+	return subset
+}
+
+// Equal returns true if the two collections have the very same elements.
+func Equal(c1, c2 Collection) bool {
+	return c1.Length() == c2.Length() && Subset(c1, c2)
+}
@@ -0,0 +1,95 @@
+package set_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aakordas/set"
+	"github.com/aakordas/set/settest"
+)
+
+func newIntSet(elems ...interface{}) set.Set {
+	s := set.NewSet()
+	s.SetType(0)
+	s.AddAll(elems...)
+
+	return s
+}
+
+func TestConformance(t *testing.T) {
+	makers := map[string]settest.Maker{
+		"Set": func(elems ...interface{}) set.Collection {
+			s := newIntSet(elems...)
+			return &s
+		},
+		"View": func(elems ...interface{}) set.Collection {
+			s := newIntSet(elems...)
+			return s.View()
+		},
+		"OrderedSet": func(elems ...interface{}) set.Collection {
+			s := set.NewOrderedSet()
+			s.SetType(0)
+			for _, elem := range elems {
+				s.Add(elem)
+			}
+			return &s
+		},
+		"SortedSet": func(elems ...interface{}) set.Collection {
+			s := set.NewSortedSet(nil)
+			s.SetType(0)
+			for _, elem := range elems {
+				s.Add(elem)
+			}
+			return &s
+		},
+		"ExpiringSet": func(elems ...interface{}) set.Collection {
+			s := set.NewExpiringSet(nil, 0)
+			s.SetType(0)
+			for _, elem := range elems {
+				s.Add(elem, time.Hour)
+			}
+			return &s
+		},
+		"BoundedSet": func(elems ...interface{}) set.Collection {
+			s := set.NewBoundedSet(100, set.NewLRU())
+			s.SetType(0)
+			for _, elem := range elems {
+				s.Add(elem)
+			}
+			return &s
+		},
+		"UnionView": func(elems ...interface{}) set.Collection {
+			// Split the elements between the operands, with some in both.
+			s1, s2 := newIntSet(), newIntSet()
+			for i, elem := range elems {
+				if i%2 == 0 {
+					s1.Add(elem)
+				}
+				if i%2 != 0 || i%3 == 0 {
+					s2.Add(elem)
+				}
+			}
+			v, _ := s1.UnionView(&s2)
+			return v
+		},
+		"IntersectionView": func(elems ...interface{}) set.Collection {
+			s1, s2 := newIntSet(elems...), newIntSet(elems...)
+			s1.Add(100)
+			s2.Add(200)
+			v, _ := s1.IntersectionView(&s2)
+			return v
+		},
+		"DifferenceView": func(elems ...interface{}) set.Collection {
+			s1, s2 := newIntSet(elems...), newIntSet(100)
+			s1.Add(100)
+			v, _ := s1.DifferenceView(&s2)
+			return v
+		},
+	}
+
+	for name, make := range makers {
+		t.Run(name, func(t *testing.T) {
+			settest.TestCollection(t, make)
+		})
+	}
+}
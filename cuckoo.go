@@ -0,0 +1,454 @@
+package set
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+	"reflect"
+)
+
+// ErrFilterFull is returned when an element cannot be added to a CuckooFilter,
+// because it has no room left.
+var ErrFilterFull = errors.New("set: the filter is full")
+
+const (
+	cuckooBucketSize = 4
+	// cuckooMaxKicks is how many fingerprints are moved to make room for a
+	// new one before the filter is considered full.
+	cuckooMaxKicks = 500
+	// cuckooMaxCopies is how many copies of a fingerprint fit in its two
+	// buckets.
+	cuckooMaxCopies = 2 * cuckooBucketSize
+)
+
+// CuckooFilter is a probabilistic set, like BloomFilter, that also supports
+// removing elements. It stores a short fingerprint of every element in one of
+// two buckets, so Has never misses an element that was added, but may report
+// one that was not, with a false-positive rate chosen when the filter is
+// created. For low rates it needs less space than a BloomFilter.
+//
+// Different elements may share a fingerprint and a pair of buckets, so the
+// filter is a multiset: it stores a fingerprint once for every time it is
+// added, and Remove removes one copy. As a consequence, an element that was
+// added several times is only gone once it has been removed as many times.
+type CuckooFilter struct {
+	slots []uint32 // cuckooBucketSize slots per bucket, 0 when empty
+	mask  uint64   // The number of buckets, a power of two, minus one
+	bits  uint     // The length of the fingerprints
+	count int
+	// victim is a fingerprint that could not be placed when the filter
+	// filled up, and victimAt one of its two buckets.
+	victim       uint32
+	victimAt     uint64
+	seed         uint64 // The state of the generator of the kicks
+	elementsType reflect.Type
+}
+
+// NewCuckooFilter allocates memory for a new, empty CuckooFilter that holds n
+// elements with a false-positive rate of at most p. It panics if n is not
+// positive or p is not in (0, 1).
+func NewCuckooFilter(n int, p float64) (c CuckooFilter) {
+	if n <= 0 {
+		panic("set: a CuckooFilter must hold at least one element")
+	}
+	if !(p > 0 && p < 1) {
+		panic("set: the false-positive rate of a CuckooFilter must be in (0, 1)")
+	}
+
+	// A lookup compares a fingerprint with the slots of two buckets, each
+	// matching by chance with probability 2^-bits.
+	c.bits = uint(math.Ceil(math.Log2(2 * cuckooBucketSize / p)))
+	if c.bits > 32 {
+		c.bits = 32
+	}
+
+	// Leave some room, since the filter rarely fills up completely.
+	buckets := uint64(math.Ceil(float64(n) / (cuckooBucketSize * 0.95)))
+	buckets = 1 << bits.Len64(buckets-1)
+
+	c.slots = make([]uint32, buckets*cuckooBucketSize)
+	c.mask = buckets - 1
+	c.seed = 0x9e3779b97f4a7c15
+
+	return c
+}
+
+// CuckooFilterOf returns a CuckooFilter with the elements and the type of the
+// set s, sized for them with a false-positive rate of p. It panics if p is not
+// in (0, 1).
+func CuckooFilterOf(s Set, p float64) (CuckooFilter, error) {
+	n := s.Length()
+	if n == 0 {
+		n = 1
+	}
+
+	c := NewCuckooFilter(n, p)
+	c.elementsType = s.elementsType
+
+	for elem := range s.Set {
+		if _, err := c.Add(elem); err != nil {
+			return CuckooFilter{}, err
+		}
+	}
+
+	return c, nil
+}
+
+// SetType sets the type of the elements the filter accepts, with the same rules
+// as Set.SetType.
+func (c *CuckooFilter) SetType(elem interface{}) error {
+	newType := reflect.ValueOf(elem).Type()
+
+	if c.elementsType == nil {
+		c.elementsType = newType
+		return nil
+	}
+
+	return &TypeError{c.elementsType, newType, "Trying to re-set the set's type."}
+}
+
+// ElementsType returns the type of the elements the filter accepts, or nil if
+// it accepts elements of any type.
+func (c *CuckooFilter) ElementsType() reflect.Type {
+	return c.elementsType
+}
+
+func (c *CuckooFilter) properType(elem interface{}) bool {
+	return c.elementsType == nil || reflect.ValueOf(elem).Type() == c.elementsType
+}
+
+// locate returns the fingerprint of elem and its two buckets.
+func (c *CuckooFilter) locate(elem interface{}) (fp uint32, i1, i2 uint64) {
+	h1, h2 := filterIndexes(elem)
+
+	fp = uint32(h2 >> (64 - c.bits))
+	if fp == 0 {
+		fp = 1
+	}
+
+	i1 = h1 & c.mask
+
+	return fp, i1, c.alt(i1, fp)
+}
+
+// alt returns the other bucket of the fingerprint fp, which is in bucket i.
+// It only depends on the fingerprint, so it can be found without the element.
+func (c *CuckooFilter) alt(i uint64, fp uint32) uint64 {
+	return (i ^ mix64(uint64(fp))) & c.mask
+}
+
+// bucket returns the slots of the i-th bucket.
+func (c *CuckooFilter) bucket(i uint64) []uint32 {
+	return c.slots[i*cuckooBucketSize : (i+1)*cuckooBucketSize]
+}
+
+// put stores fp in an empty slot of the i-th bucket, if there is one.
+func (c *CuckooFilter) put(fp uint32, i uint64) bool {
+	b := c.bucket(i)
+	for j, v := range b {
+		if v == 0 {
+			b[j] = fp
+			return true
+		}
+	}
+
+	return false
+}
+
+// contains returns true if fp is stored in the i1-th or the i2-th bucket, or
+// is the victim of one of them.
+func (c *CuckooFilter) contains(fp uint32, i1, i2 uint64) bool {
+	for _, i := range []uint64{i1, i2} {
+		for _, v := range c.bucket(i) {
+			if v == fp {
+				return true
+			}
+		}
+	}
+
+	return c.victim == fp && (c.victimAt == i1 || c.victimAt == i2)
+}
+
+// copies returns how many times fp is stored in the i1-th and the i2-th
+// bucket, including as their victim.
+func (c *CuckooFilter) copies(fp uint32, i1, i2 uint64) int {
+	n := 0
+	for _, v := range c.bucket(i1) {
+		if v == fp {
+			n++
+		}
+	}
+
+	if i2 != i1 {
+		for _, v := range c.bucket(i2) {
+			if v == fp {
+				n++
+			}
+		}
+	}
+
+	if c.victim == fp && (c.victimAt == i1 || c.victimAt == i2) {
+		n++
+	}
+
+	return n
+}
+
+// maxCopies returns how many copies of a fingerprint fit in the i1-th and the
+// i2-th bucket, which hold the copies of a single bucket if they are the same.
+func maxCopies(i1, i2 uint64) int {
+	if i1 == i2 {
+		return cuckooBucketSize
+	}
+
+	return cuckooMaxCopies
+}
+
+// random returns the next number of the generator of the kicks.
+func (c *CuckooFilter) random() uint64 {
+	// xorshift64
+	c.seed ^= c.seed << 13
+	c.seed ^= c.seed >> 7
+	c.seed ^= c.seed << 17
+
+	return c.seed
+}
+
+// insert stores fp in the i-th bucket or in its other one, moving other
+// fingerprints to their other buckets to make room if needed. If the filter
+// already has a victim, ErrFilterFull is returned.
+func (c *CuckooFilter) insert(fp uint32, i uint64) error {
+	if c.victim != 0 {
+		return ErrFilterFull
+	}
+
+	c.count++
+	if c.put(fp, i) || c.put(fp, c.alt(i, fp)) {
+		return nil
+	}
+
+	if c.random()&1 == 0 {
+		i = c.alt(i, fp)
+	}
+
+	for n := 0; n < cuckooMaxKicks; n++ {
+		b := c.bucket(i)
+		j := c.random() % cuckooBucketSize
+		fp, b[j] = b[j], fp
+
+		i = c.alt(i, fp)
+		if c.put(fp, i) {
+			return nil
+		}
+	}
+
+	// The fingerprint left over is kept aside, so that no element is lost,
+	// but no more can be added.
+	c.victim, c.victimAt = fp, i
+
+	return nil
+}
+
+// Add adds elem to the filter c and returns true. If the element is not of the
+// correct type, nothing changes and false is returned. Since the filter is a
+// multiset, a copy of the fingerprint is stored even if the filter already
+// reports the element as present, which may be a false positive. If the filter
+// is full, or already holds as many copies of the fingerprint as fit in its
+// buckets, nothing changes and ErrFilterFull is returned.
+func (c *CuckooFilter) Add(elem interface{}) (bool, error) {
+	if !c.properType(elem) {
+		return false, nil
+	}
+
+	fp, i1, i2 := c.locate(elem)
+	if c.copies(fp, i1, i2) >= maxCopies(i1, i2) {
+		return false, ErrFilterFull
+	}
+
+	if err := c.insert(fp, i1); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Has returns true if elem was probably added to the filter c, and false if it
+// certainly was not.
+func (c *CuckooFilter) Has(elem interface{}) bool {
+	if !c.properType(elem) || len(c.slots) == 0 {
+		return false
+	}
+
+	return c.contains(c.locate(elem))
+}
+
+// Remove removes elem from the filter c, and returns true if it was found.
+// Only elements that were added should be removed: removing a false positive
+// removes another element instead.
+func (c *CuckooFilter) Remove(elem interface{}) bool {
+	if !c.properType(elem) || len(c.slots) == 0 {
+		return false
+	}
+
+	fp, i1, i2 := c.locate(elem)
+
+	if c.victim == fp && (c.victimAt == i1 || c.victimAt == i2) {
+		c.victim = 0
+		c.count--
+		return true
+	}
+
+	for _, i := range []uint64{i1, i2} {
+		b := c.bucket(i)
+		for j, v := range b {
+			if v != fp {
+				continue
+			}
+
+			b[j] = 0
+			c.count--
+
+			// There is room for the victim now.
+			if victim := c.victim; victim != 0 {
+				c.victim = 0
+				c.count--
+				c.insert(victim, c.victimAt)
+			}
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// Length returns the number of fingerprints in the filter c: the number of
+// elements, counting each once for every time it was added.
+func (c *CuckooFilter) Length() int {
+	return c.count
+}
+
+// Empty returns true if the filter c has no elements.
+func (c *CuckooFilter) Empty() bool {
+	return c.count == 0
+}
+
+// Capacity returns the number of fingerprints the filter c has room for. It
+// usually fills up at around 95% of its capacity.
+func (c *CuckooFilter) Capacity() int {
+	return len(c.slots)
+}
+
+// Union returns a filter that reports every element that c1 or c2 reports.
+// The filters must have been created with the same size and false-positive
+// rate, otherwise ErrIncompatibleFilters is returned, and with the same type,
+// otherwise a TypeError is returned. Every fingerprint of c2 is added to those
+// of c1, so elements of both are stored twice. If they do not fit in a single
+// filter, ErrFilterFull is returned.
+func (c1 *CuckooFilter) Union(c2 CuckooFilter) (CuckooFilter, error) {
+	if c1.bits != c2.bits || len(c1.slots) != len(c2.slots) {
+		return CuckooFilter{}, ErrIncompatibleFilters
+	}
+
+	if c1.elementsType != c2.elementsType {
+		return CuckooFilter{}, &TypeError{c1.elementsType, c2.elementsType,
+			"The filters' types do not match."}
+	}
+
+	c := *c1
+	c.slots = append([]uint32(nil), c1.slots...)
+
+	add := func(fp uint32, i uint64) error {
+		if fp == 0 {
+			return nil
+		}
+
+		if j := c.alt(i, fp); c.copies(fp, i, j) >= maxCopies(i, j) {
+			return ErrFilterFull
+		}
+
+		return c.insert(fp, i)
+	}
+
+	for k, fp := range c2.slots {
+		if err := add(fp, uint64(k/cuckooBucketSize)); err != nil {
+			return CuckooFilter{}, err
+		}
+	}
+
+	if err := add(c2.victim, c2.victimAt); err != nil {
+		return CuckooFilter{}, err
+	}
+
+	return c, nil
+}
+
+// The binary format of a CuckooFilter, all integers little-endian:
+//
+//	magic     "CKOF"
+//	bits      uint8, the length of the fingerprints
+//	count     uint64, of elements
+//	buckets   uint64, a power of two
+//	victim    uint32, 0 if there is none
+//	victimAt  uint64, its bucket
+//	payload   4 uint32s per bucket, the fingerprints or 0
+//
+// The type of the filter is not included.
+const cuckooMagic = "CKOF"
+
+var errCuckooFormat = errors.New("set: invalid CuckooFilter encoding")
+
+// MarshalBinary encodes the filter c in a portable binary format.
+func (c CuckooFilter) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, 33+4*len(c.slots))
+	buf = append(buf, cuckooMagic...)
+	buf = append(buf, uint8(c.bits))
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(c.count))
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(len(c.slots)/cuckooBucketSize))
+	buf = binary.LittleEndian.AppendUint32(buf, c.victim)
+	buf = binary.LittleEndian.AppendUint64(buf, c.victimAt)
+
+	for _, fp := range c.slots {
+		buf = binary.LittleEndian.AppendUint32(buf, fp)
+	}
+
+	return buf, nil
+}
+
+// UnmarshalBinary decodes a filter that was encoded with MarshalBinary. The
+// filter keeps its type, if it has one.
+func (c *CuckooFilter) UnmarshalBinary(data []byte) error {
+	if len(data) < 33 || string(data[:4]) != cuckooMagic {
+		return errCuckooFormat
+	}
+
+	fpBits := uint(data[4])
+	count := int(binary.LittleEndian.Uint64(data[5:]))
+	buckets := binary.LittleEndian.Uint64(data[13:])
+	victim := binary.LittleEndian.Uint32(data[21:])
+	victimAt := binary.LittleEndian.Uint64(data[25:])
+	data = data[33:]
+
+	if fpBits == 0 || fpBits > 32 || buckets == 0 || buckets&(buckets-1) != 0 ||
+		uint64(len(data))/(4*cuckooBucketSize) != buckets || len(data)%(4*cuckooBucketSize) != 0 ||
+		victimAt >= buckets || uint64(victim)>>fpBits != 0 {
+		return errCuckooFormat
+	}
+
+	slots := make([]uint32, buckets*cuckooBucketSize)
+	for i := range slots {
+		slots[i] = binary.LittleEndian.Uint32(data[4*i:])
+		if uint64(slots[i])>>fpBits != 0 {
+			return errCuckooFormat
+		}
+	}
+
+	c.slots, c.mask, c.bits, c.count = slots, buckets-1, fpBits, count
+	c.victim, c.victimAt = victim, victimAt
+	if c.seed == 0 {
+		c.seed = 0x9e3779b97f4a7c15
+	}
+
+	return nil
+}
@@ -0,0 +1,270 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestCuckooFilter(t *testing.T) {
+	const n = 10000
+
+	for _, p := range []float64{0.1, 0.01, 0.001} {
+		c := NewCuckooFilter(n, p)
+		for i := 0; i < n; i++ {
+			if _, err := c.Add(i); err != nil {
+				t.Fatalf("Adding %d to a filter for %d elements returned %v.", i, n, err)
+			}
+		}
+
+		for i := 0; i < n; i++ {
+			if !c.Has(i) {
+				t.Fatalf("The element %d was added to the filter, but is missing.", i)
+			}
+		}
+
+		if rate := falsePositiveRate(c.Has, n, 100000); rate > 1.5*p {
+			t.Errorf("The false-positive rate of a filter for %v is %v.", p, rate)
+		}
+	}
+}
+
+func TestCuckooFilterRemove(t *testing.T) {
+	c := NewCuckooFilter(1000, 0.001)
+	for i := 0; i < 1000; i++ {
+		c.Add(i)
+	}
+
+	n := c.Length()
+	for i := 0; i < 1000; i += 2 {
+		if !c.Remove(i) {
+			t.Errorf("The element %d was not removed.", i)
+		}
+	}
+
+	if c.Length() != n-500 {
+		t.Errorf("The filter has %d elements, instead of %d.", c.Length(), n-500)
+	}
+
+	for i := 1; i < 1000; i += 2 {
+		if !c.Has(i) {
+			t.Fatalf("The element %d is missing after other elements were removed.", i)
+		}
+	}
+
+	if rate := falsePositiveRate(c.Has, 0, 1000); rate > 0.5+0.01 {
+		t.Errorf("Half of the elements were removed, but %v are reported.", rate)
+	}
+}
+
+func TestCuckooFilterFull(t *testing.T) {
+	c := NewCuckooFilter(100, 0.01)
+
+	var err error
+	i := 0
+	for ; err == nil; i++ {
+		_, err = c.Add(i)
+	}
+
+	if err != ErrFilterFull || c.Length() < c.Capacity()*9/10 {
+		t.Errorf("The filter filled up at %d of %d, with %v.", c.Length(), c.Capacity(), err)
+	}
+
+	// Every element added before the filter filled up, including the one
+	// that filled it, must still be found.
+	for j := 0; j < i-1; j++ {
+		if !c.Has(j) {
+			t.Fatalf("The element %d is missing from the full filter.", j)
+		}
+	}
+
+	c.Remove(0)
+	if _, err := c.Add(-1); err != nil {
+		t.Errorf("Adding to the filter after a removal returned %v.", err)
+	}
+}
+
+func TestCuckooFilterCollisions(t *testing.T) {
+	// A filter with a single bucket and fingerprints of 4 bits, so that
+	// different elements share their fingerprint and buckets.
+	c := NewCuckooFilter(1, 0.5)
+
+	var x, y int
+	for seen := make(map[uint32]int); ; y++ {
+		fp, _, _ := c.locate(y)
+		if prev, ok := seen[fp]; ok {
+			x = prev
+			break
+		}
+		seen[fp] = y
+	}
+
+	if ok, err := c.Add(x); !ok || err != nil {
+		t.Fatalf("Adding %d returned %v, %v.", x, ok, err)
+	}
+	if ok, err := c.Add(y); !ok || err != nil || c.Length() != 2 {
+		t.Errorf("Adding %d, which collides with %d, returned %v, %v.", y, x, ok, err)
+	}
+
+	if !c.Remove(x) || !c.Has(y) || c.Length() != 1 {
+		t.Errorf("Removing %d also removed %d, which collides with it.", x, y)
+	}
+
+	if !c.Remove(y) || c.Has(x) || c.Has(y) || !c.Empty() {
+		t.Errorf("The filter is not empty after removing %d and %d.", x, y)
+	}
+}
+
+func TestCuckooFilterCopies(t *testing.T) {
+	c := NewCuckooFilter(1000, 0.01)
+
+	// An element whose two buckets differ, so that both hold its copies.
+	elem := 0
+	for {
+		if _, i1, i2 := c.locate(elem); i1 != i2 {
+			break
+		}
+		elem++
+	}
+
+	for i := 0; i < 2*cuckooBucketSize; i++ {
+		if _, err := c.Add(elem); err != nil {
+			t.Fatalf("Adding copy %d of %d returned %v.", i+1, elem, err)
+		}
+	}
+
+	if _, err := c.Add(elem); err != ErrFilterFull || c.Length() != 2*cuckooBucketSize {
+		t.Errorf("Adding one copy too many of %d returned %v.", elem, err)
+	}
+
+	for i := 0; i < 2*cuckooBucketSize; i++ {
+		if !c.Has(elem) || !c.Remove(elem) {
+			t.Fatalf("Copy %d of %d was lost.", i+1, elem)
+		}
+	}
+
+	if c.Has(elem) {
+		t.Errorf("The element %d was removed as many times as it was added, but is present.", elem)
+	}
+}
+
+func TestCuckooFilterSameBuckets(t *testing.T) {
+	c := NewCuckooFilter(1000, 0.01)
+
+	// An element whose two buckets are the same, so that only one holds its
+	// copies.
+	elem := 0
+	for {
+		if _, i1, i2 := c.locate(elem); i1 == i2 {
+			break
+		}
+		elem++
+	}
+
+	for i := 0; i < cuckooBucketSize; i++ {
+		if ok, err := c.Add(elem); !ok || err != nil {
+			t.Fatalf("Adding copy %d of %d returned %v, %v.", i+1, elem, ok, err)
+		}
+	}
+
+	if _, err := c.Add(elem); err != ErrFilterFull || c.Length() != cuckooBucketSize {
+		t.Errorf("Adding one copy too many of %d returned %v.", elem, err)
+	}
+
+	// No copy was kept aside, so the filter still has room.
+	other := elem + 1
+	if ok, err := c.Add(other); !ok || err != nil || !c.Has(other) {
+		t.Errorf("Adding %d after the copies of %d returned %v, %v.", other, elem, ok, err)
+	}
+
+	for i := 0; i < cuckooBucketSize; i++ {
+		if !c.Has(elem) || !c.Remove(elem) {
+			t.Fatalf("Copy %d of %d was lost.", i+1, elem)
+		}
+	}
+
+	if c.Has(elem) {
+		t.Errorf("The element %d was removed as many times as it was added, but is present.", elem)
+	}
+}
+
+func TestCuckooFilterOf(t *testing.T) {
+	s := CreateSet("a")
+	s.AddAll("b", "c")
+
+	c, err := CuckooFilterOf(s, 0.01)
+	if err != nil || !c.Has("a") || c.Has(1) || c.Length() != 3 || c.ElementsType() != s.ElementsType() {
+		t.Errorf("The filter of %v has the wrong elements or type.", s)
+	}
+
+	if ok, _ := c.Add(1); ok {
+		t.Errorf("The filter of %v accepted an integer.", s)
+	}
+
+	// The filter is a multiset, so a duplicate is stored again.
+	if ok, err := c.Add("a"); !ok || err != nil || c.Length() != 4 {
+		t.Errorf("Adding a duplicate to the filter of %v returned %v, %v.", s, ok, err)
+	}
+}
+
+func TestCuckooFilterUnion(t *testing.T) {
+	c1, c2 := NewCuckooFilter(1000, 0.01), NewCuckooFilter(1000, 0.01)
+	for i := 0; i < 400; i++ {
+		c1.Add(i)
+		c2.Add(i + 300)
+	}
+
+	c, err := c1.Union(c2)
+	if err != nil {
+		t.Fatalf("The union of the filters returned %v.", err)
+	}
+
+	for i := 0; i < 700; i++ {
+		if !c.Has(i) {
+			t.Fatalf("The element %d is missing from the union.", i)
+		}
+	}
+
+	// The elements of both filters are stored twice.
+	if c.Length() != 800 || c1.Length() != 400 {
+		t.Errorf("The union has %d fingerprints, instead of 800.", c.Length())
+	}
+
+	for i := 300; i < 400; i++ {
+		if !c.Remove(i) || !c.Has(i) {
+			t.Fatalf("The element %d, added to both filters, was lost after one removal.", i)
+		}
+	}
+
+	if _, err := c1.Union(NewCuckooFilter(1000, 0.0001)); err != ErrIncompatibleFilters {
+		t.Errorf("The union of incompatible filters returned %v.", err)
+	}
+}
+
+func TestCuckooFilterBinary(t *testing.T) {
+	c := NewCuckooFilter(100, 0.01)
+	for i := 0; i < 100; i++ {
+		c.Add(i)
+	}
+
+	data, _ := c.MarshalBinary()
+
+	var decoded CuckooFilter
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Decoding the filter returned %v.", err)
+	}
+
+	for i := 0; i < 100; i++ {
+		if !decoded.Has(i) {
+			t.Fatalf("The element %d is missing from the decoded filter.", i)
+		}
+	}
+
+	if !decoded.Remove(5) || decoded.Length() != c.Length()-1 {
+		t.Errorf("The decoded filter does not behave as the original.")
+	}
+
+	for _, bad := range [][]byte{nil, data[:len(data)-4], append([]byte("XXXX"), data[4:]...)} {
+		if err := decoded.UnmarshalBinary(bad); err == nil {
+			t.Errorf("Decoding %d bytes returned no error.", len(bad))
+		}
+	}
+}
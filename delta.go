@@ -0,0 +1,273 @@
+package set
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Delta holds the changes that turn one set into another: the elements that
+// were added and the elements that were removed. Added has the type of the
+// new set and Removed the type of the old one.
+type Delta struct {
+	Added   Set
+	Removed Set
+}
+
+// ConflictError indicates that a Delta does not fit the set it is applied to.
+// It holds the elements the delta adds although they already exist in the set
+// and the elements it removes although they do not.
+type ConflictError struct {
+	Present []interface{} // Added elements that already exist in the set
+	Absent  []interface{} // Removed elements that do not exist in the set
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("The delta conflicts with the set: %d added elements are already present and %d removed elements are absent.",
+		len(e.Present), len(e.Absent))
+}
+
+// Diff returns the Delta that turns the set from into the set to.
+func Diff(from, to Set) Delta {
+	d := Delta{Added: NewSet(), Removed: NewSet()}
+	d.Added.elementsType = to.elementsType
+	d.Removed.elementsType = from.elementsType
+
+	for v := range to.Set {
+		if _, ok := from.Set[v]; !ok {
+			d.Added.Add(v)
+		}
+	}
+
+	for v := range from.Set {
+		if _, ok := to.Set[v]; !ok {
+			d.Removed.Add(v)
+		}
+	}
+
+	return d
+}
+
+// Invert returns the Delta that undoes d.
+func (d Delta) Invert() Delta {
+	return Delta{Added: d.Removed, Removed: d.Added}
+}
+
+// Empty returns true if the delta changes nothing, otherwise false.
+func (d Delta) Empty() bool {
+	return d.Added.Empty() && d.Removed.Empty()
+}
+
+// Apply applies the delta d to the set s. As with Union, the elements of the
+// delta must have the same type as the set, unless either of them is empty,
+// otherwise a TypeError is returned. Every added element must also be one Add
+// would accept, otherwise the error Validate returns for it is returned. If d
+// adds an element that already exists in s, or removes one that does not, a
+// ConflictError is returned. In all cases s is left unchanged. Observers are
+// notified once for the whole delta.
+func (s *Set) Apply(d Delta) error {
+	for _, part := range []Set{d.Added, d.Removed} {
+		if !s.Empty() && !part.Empty() && !s.SameType(part) {
+			return &TypeError{s.elementsType, part.elementsType,
+				"The types of the set and the delta do not match."}
+		}
+	}
+
+	for v := range d.Added.Set {
+		if err := s.Validate(v); err != nil {
+			return err
+		}
+	}
+
+	var conflict ConflictError
+	for v := range d.Added.Set {
+		if _, ok := s.Set[v]; ok {
+			conflict.Present = append(conflict.Present, v)
+		}
+	}
+	for v := range d.Removed.Set {
+		if _, ok := s.Set[v]; !ok {
+			conflict.Absent = append(conflict.Absent, v)
+		}
+	}
+	if conflict.Present != nil || conflict.Absent != nil {
+		return &conflict
+	}
+
+	defer s.observers.batch()()
+
+	for v := range d.Removed.Set {
+		s.Remove(v)
+	}
+	for v := range d.Added.Set {
+		s.Add(v)
+	}
+
+	return nil
+}
+
+// jsonSet and jsonElement are the JSON forms of a set and of its elements.
+// Every element carries the name of its type, since a set may hold elements of
+// different types.
+type jsonSet struct {
+	Type     string        `json:"type,omitempty"`
+	Elements []jsonElement `json:"elements"`
+}
+
+type jsonElement struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// MarshalJSON encodes the delta d as JSON. The types of the elements must be
+// registered with RegisterType in order to decode it.
+func (d Delta) MarshalJSON() ([]byte, error) {
+	added, err := encodeJSONSet(d.Added)
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := encodeJSONSet(d.Removed)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(struct {
+		Added   jsonSet `json:"added"`
+		Removed jsonSet `json:"removed"`
+	}{added, removed})
+}
+
+// UnmarshalJSON decodes a delta that was encoded with MarshalJSON.
+func (d *Delta) UnmarshalJSON(data []byte) error {
+	var wire struct {
+		Added   jsonSet `json:"added"`
+		Removed jsonSet `json:"removed"`
+	}
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+
+	added, err := decodeJSONSet(wire.Added)
+	if err != nil {
+		return err
+	}
+
+	removed, err := decodeJSONSet(wire.Removed)
+	if err != nil {
+		return err
+	}
+
+	d.Added, d.Removed = added, removed
+
+	return nil
+}
+
+func encodeJSONSet(s Set) (jsonSet, error) {
+	js := jsonSet{Type: nameOf(s.elementsType), Elements: []jsonElement{}}
+
+	for v := range s.Set {
+		value, err := json.Marshal(v)
+		if err != nil {
+			return jsonSet{}, err
+		}
+
+		js.Elements = append(js.Elements, jsonElement{nameOf(reflect.TypeOf(v)), value})
+	}
+
+	return js, nil
+}
+
+func decodeJSONSet(js jsonSet) (Set, error) {
+	s := NewSet()
+
+	t, err := lookupType(js.Type)
+	if err != nil {
+		return Set{}, err
+	}
+	s.elementsType = t
+
+	for _, e := range js.Elements {
+		t, err := lookupType(e.Type)
+		if err != nil {
+			return Set{}, err
+		}
+
+		// The empty type name is only written for nil elements.
+		if t == nil {
+			s.Add(nil)
+			continue
+		}
+
+		v := reflect.New(t)
+		if err := json.Unmarshal(e.Value, v.Interface()); err != nil {
+			return Set{}, err
+		}
+
+		s.Add(v.Elem().Interface())
+	}
+
+	return s, nil
+}
+
+// gobDelta is the binary form of a delta.
+type gobDelta struct {
+	AddedType, RemovedType string
+	Added, Removed         []interface{}
+}
+
+// MarshalBinary encodes the delta d with encoding/gob. The types of the
+// elements must be registered with RegisterType in order to decode it.
+func (d Delta) MarshalBinary() ([]byte, error) {
+	wire := gobDelta{
+		AddedType:   nameOf(d.Added.elementsType),
+		RemovedType: nameOf(d.Removed.elementsType),
+	}
+
+	for v := range d.Added.Set {
+		wire.Added = append(wire.Added, v)
+	}
+	for v := range d.Removed.Set {
+		wire.Removed = append(wire.Removed, v)
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(wire); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a delta that was encoded with MarshalBinary.
+func (d *Delta) UnmarshalBinary(data []byte) error {
+	var wire gobDelta
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&wire); err != nil {
+		return err
+	}
+
+	addedType, err := lookupType(wire.AddedType)
+	if err != nil {
+		return err
+	}
+
+	removedType, err := lookupType(wire.RemovedType)
+	if err != nil {
+		return err
+	}
+
+	d.Added, d.Removed = NewSet(), NewSet()
+	d.Added.elementsType = addedType
+	d.Removed.elementsType = removedType
+
+	for _, v := range wire.Added {
+		d.Added.Add(v)
+	}
+	for _, v := range wire.Removed {
+		d.Removed.Add(v)
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package set
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDiffApply(t *testing.T) {
+	from := CreateSet(1)
+	from.Add(2)
+	to := CreateSet(2)
+	to.Add(3)
+
+	d := Diff(from, to)
+
+	if !d.Added.Equal(CreateSet(3)) || !d.Removed.Equal(CreateSet(1)) {
+		t.Errorf("The delta from %v to %v is %v.", from, to, d)
+	}
+
+	s := CreateSet(1)
+	s.Add(2)
+	if err := s.Apply(d); err != nil {
+		t.Errorf("There was an error trying to apply %v to %v.\n%v", d, s, err)
+	}
+
+	if !s.Equal(to) {
+		t.Errorf("Applying %v resulted in %v, instead of %v.", d, s, to)
+	}
+
+	if err := s.Apply(d.Invert()); err != nil {
+		t.Errorf("There was an error trying to apply %v to %v.\n%v", d.Invert(), s, err)
+	}
+
+	if !s.Equal(from) {
+		t.Errorf("Applying the inverted delta resulted in %v, instead of %v.", s, from)
+	}
+
+	if !Diff(from, from).Empty() {
+		t.Errorf("The delta of %v from itself is not empty.", from)
+	}
+}
+
+func TestApplyConflicts(t *testing.T) {
+	d := Diff(CreateSet(1), CreateSet(2))
+
+	s := CreateSet(2)
+	err := s.Apply(d)
+	conflict, ok := err.(*ConflictError)
+	if !ok {
+		t.Fatalf("Applying %v to %v did not fail with a conflict, but with %v.", d, s, err)
+	}
+
+	if len(conflict.Present) != 1 || len(conflict.Absent) != 1 {
+		t.Errorf("The conflict %v does not report both conflicting elements.", conflict)
+	}
+
+	if !s.Equal(CreateSet(2)) {
+		t.Errorf("The set %v changed after a conflicting delta.", s)
+	}
+
+	s = CreateSet("a")
+	if _, ok := s.Apply(d).(*TypeError); !ok {
+		t.Errorf("Applying %v to %v did not fail with a TypeError.", d, s)
+	}
+}
+
+func TestDeltaEncoding(t *testing.T) {
+	type point struct {
+		X, Y int
+	}
+	RegisterType(point{})
+
+	from := NewSet()
+	from.Add(1)
+	from.Add("a")
+	to := CreateSet(point{1, 2})
+
+	d := Diff(from, to)
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("There was an error trying to encode %v as JSON.\n%v", d, err)
+	}
+
+	var got Delta
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("There was an error trying to decode %s.\n%v", data, err)
+	}
+
+	if !got.Added.Equal(d.Added) || !got.Removed.Equal(d.Removed) || !got.Added.SameType(d.Added) {
+		t.Errorf("Decoding %s resulted in %v, instead of %v.", data, got, d)
+	}
+
+	data, err = d.MarshalBinary()
+	if err != nil {
+		t.Fatalf("There was an error trying to encode %v.\n%v", d, err)
+	}
+
+	got = Delta{}
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatalf("There was an error trying to decode %v.\n%v", data, err)
+	}
+
+	if !got.Added.Equal(d.Added) || !got.Removed.Equal(d.Removed) || !got.Added.SameType(d.Added) {
+		t.Errorf("Decoding %v resulted in %v, instead of %v.", data, got, d)
+	}
+
+	withNil := NewSet()
+	withNil.Add(nil)
+	withNil.Add(1)
+	d = Diff(NewSet(), withNil)
+
+	data, err = json.Marshal(d)
+	if err != nil {
+		t.Fatalf("There was an error trying to encode %v as JSON.\n%v", d, err)
+	}
+
+	got = Delta{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("There was an error trying to decode %s.\n%v", data, err)
+	}
+
+	if !got.Added.Equal(withNil) || !got.Added.Has(nil) {
+		t.Errorf("Decoding %s resulted in %v, instead of %v.", data, got.Added, withNil)
+	}
+
+	if err := got.UnmarshalJSON([]byte(`{"added":{"elements":[{"type":"unknown","value":1}]}}`)); err == nil {
+		t.Errorf("Decoding an element of an unregistered type succeeded.")
+	}
+}
@@ -0,0 +1,174 @@
+package set
+
+import "reflect"
+
+// DisjointSets keeps a collection of disjoint sets of elements, its
+// components, and merges them: the union-find structure. Find and Union take
+// nearly constant amortized time, thanks to path compression and union by
+// rank. Like a Set, it may optionally accept elements of a single type only.
+type DisjointSets struct {
+	parent       map[interface{}]interface{}
+	rank         map[interface{}]int
+	count        int
+	elementsType reflect.Type
+}
+
+// NewDisjointSets allocates memory for a new DisjointSets without elements. It
+// accepts elements of varying types, unless SetType is called.
+func NewDisjointSets() (d DisjointSets) {
+	d.parent = make(map[interface{}]interface{})
+	d.rank = make(map[interface{}]int)
+
+	return d
+}
+
+// SetType sets the type of the elements d accepts. As with Set, the type can
+// only be set once, otherwise a TypeError is returned.
+func (d *DisjointSets) SetType(elem interface{}) error {
+	newType := reflect.ValueOf(elem).Type()
+
+	if d.elementsType == nil {
+		d.elementsType = newType
+		return nil
+	}
+
+	return &TypeError{d.elementsType, newType, "Trying to re-set the set's type."}
+}
+
+// ElementsType returns the type of the elements d accepts, or nil if it accepts
+// elements of any type.
+func (d *DisjointSets) ElementsType() reflect.Type {
+	return d.elementsType
+}
+
+func (d *DisjointSets) properType(elem interface{}) bool {
+	return d.elementsType == nil || reflect.ValueOf(elem).Type() == d.elementsType
+}
+
+// Add adds elem to d as a component of its own. If the element already exists
+// or is not of the correct type, nothing is added and false is returned.
+func (d *DisjointSets) Add(elem interface{}) bool {
+	if !d.properType(elem) {
+		return false
+	}
+
+	if _, ok := d.parent[elem]; ok {
+		return false
+	}
+
+	d.parent[elem] = elem
+	d.count++
+
+	return true
+}
+
+// Has returns true if elem is an element of d.
+func (d *DisjointSets) Has(elem interface{}) bool {
+	_, ok := d.parent[elem]
+
+	return ok
+}
+
+// Length returns the number of elements of d.
+func (d *DisjointSets) Length() int {
+	return len(d.parent)
+}
+
+// Count returns the number of components of d.
+func (d *DisjointSets) Count() int {
+	return d.count
+}
+
+// Find returns the representative of the component of elem, and true. Elements
+// of the same component have the same representative, though it may change
+// when components are merged. If elem is not an element of d, it returns
+// false.
+func (d *DisjointSets) Find(elem interface{}) (interface{}, bool) {
+	if !d.Has(elem) {
+		return nil, false
+	}
+
+	root := elem
+	for d.parent[root] != root {
+		root = d.parent[root]
+	}
+
+	// Point every element on the path straight at the root.
+	for elem != root {
+		elem, d.parent[elem] = d.parent[elem], root
+	}
+
+	return root, true
+}
+
+// Union merges the components of a and b, adding either of them to d first if
+// needed. It returns true if they were in different components. If either is
+// not of the correct type, nothing changes and a TypeError is returned.
+func (d *DisjointSets) Union(a, b interface{}) (bool, error) {
+	for _, elem := range []interface{}{a, b} {
+		if !d.properType(elem) {
+			return false, &TypeError{d.elementsType, reflect.TypeOf(elem),
+				"The element's type does not match the set's."}
+		}
+	}
+
+	d.Add(a)
+	d.Add(b)
+
+	ra, _ := d.Find(a)
+	rb, _ := d.Find(b)
+	if ra == rb {
+		return false, nil
+	}
+
+	// Attach the shallower tree under the deeper one. Ranks are only kept
+	// for roots, and a missing rank is 0.
+	if d.rank[ra] < d.rank[rb] {
+		ra, rb = rb, ra
+	}
+
+	d.parent[rb] = ra
+	if d.rank[ra] == d.rank[rb] {
+		d.rank[ra]++
+	}
+	delete(d.rank, rb)
+	d.count--
+
+	return true, nil
+}
+
+// Connected returns true if a and b are elements of the same component of d.
+func (d *DisjointSets) Connected(a, b interface{}) bool {
+	ra, ok := d.Find(a)
+	if !ok {
+		return false
+	}
+
+	rb, ok := d.Find(b)
+
+	return ok && ra == rb
+}
+
+// Partition returns the components of d as a Partition of its elements.
+func (d *DisjointSets) Partition() Partition {
+	blocks := make(map[interface{}][]interface{}, d.count)
+	for elem := range d.parent {
+		root, _ := d.Find(elem)
+		blocks[root] = append(blocks[root], elem)
+	}
+
+	p := make([][]interface{}, 0, len(blocks))
+	for _, b := range blocks {
+		p = append(p, b)
+	}
+
+	return newPartition(d.elementsType, p)
+}
+
+// Components returns the components of d as new sets, with the type of d. They
+// are in the order of the blocks of Partition.
+func (d *DisjointSets) Components() []Set {
+	p := d.Partition()
+
+	return p.Blocks()
+}
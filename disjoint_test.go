@@ -0,0 +1,80 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestDisjointSets(t *testing.T) {
+	d := NewDisjointSets()
+	edges := [][2]string{{"a", "b"}, {"c", "d"}, {"b", "c"}, {"e", "f"}, {"a", "d"}}
+	merged := 0
+	for _, e := range edges {
+		ok, err := d.Union(e[0], e[1])
+		if err != nil {
+			t.Errorf("The union of %v and %v returned %v.", e[0], e[1], err)
+		}
+		if ok {
+			merged++
+		}
+	}
+
+	if merged != 4 {
+		t.Errorf("The unions merged %d times, instead of 4.", merged)
+	}
+
+	if !d.Add("g") || d.Add("a") {
+		t.Errorf("The element g could not be added, or a was added again.")
+	}
+
+	if d.Length() != 7 || d.Count() != 3 {
+		t.Errorf("There are %d elements in %d components.", d.Length(), d.Count())
+	}
+
+	if !d.Connected("a", "d") || d.Connected("a", "e") || d.Connected("a", "z") {
+		t.Errorf("The components are wrong.")
+	}
+
+	ra, _ := d.Find("a")
+	rc, _ := d.Find("c")
+	if _, ok := d.Find("z"); ok || ra != rc {
+		t.Errorf("The representatives of a and c are %v and %v.", ra, rc)
+	}
+
+	components := d.Components()
+	if len(components) != 3 || components[0].Length() != 4 || !components[2].Has("g") {
+		t.Errorf("The components are %v.", components)
+	}
+}
+
+func TestDisjointSetsType(t *testing.T) {
+	d := NewDisjointSets()
+	if err := d.SetType(0); err != nil {
+		t.Errorf("Setting the type returned %v.", err)
+	}
+
+	if err := d.SetType(""); err == nil {
+		t.Errorf("The type was set twice.")
+	}
+
+	if d.Add("a") {
+		t.Errorf("A string was added to %v elements.", d.ElementsType())
+	}
+
+	_, err := d.Union(1, "a")
+	if _, ok := err.(*TypeError); !ok {
+		t.Errorf("The union of 1 and a returned %v.", err)
+	}
+
+	if d.Has(1) {
+		t.Errorf("A failed union added an element.")
+	}
+
+	for i := 1; i < 1000; i++ {
+		d.Union(i-1, i)
+	}
+
+	components := d.Components()
+	if d.Count() != 1 || components[0].Length() != 1000 || components[0].ElementsType() != d.ElementsType() {
+		t.Errorf("The chain was not merged into a single component.")
+	}
+}
@@ -0,0 +1,82 @@
+package set
+
+import (
+	"errors"
+	"reflect"
+)
+
+// ErrNoDomain is returned by operations that need a set to be bound to a
+// Universe, when it is not.
+var ErrNoDomain = errors.New("set: the set is not bound to a universe")
+
+// Bind binds the set s to the universe u. From then on, only values of u can
+// be added to s, and the complement of s is defined, relative to u. If s has
+// a type other than that of u, a TypeError is returned; if it already has an
+// element outside of u, a DomainError. In both cases, s is left unbound. If s
+// has no type, it gets the type of u.
+func (s *Set) Bind(u *Universe) error {
+	if s.elementsType != nil && u.elementsType != nil && s.elementsType != u.elementsType {
+		return &TypeError{s.elementsType, u.elementsType, "The types of the set and the universe do not match."}
+	}
+
+	for elem := range s.Set {
+		if !u.Has(elem) {
+			return &DomainError{Elem: elem}
+		}
+	}
+
+	if s.elementsType == nil {
+		s.elementsType = u.elementsType
+	}
+	s.domain = u
+
+	return nil
+}
+
+// Domain returns the universe the set s is bound to, or nil if it is not bound
+// to one.
+func (s *Set) Domain() *Universe {
+	return s.domain
+}
+
+// inDomain checks if elem belongs to the universe of the set s, if it has one.
+func (s *Set) inDomain(elem interface{}) bool {
+	return s.domain == nil || s.domain.Has(elem)
+}
+
+// Validate returns the reason Add would reject elem: a TypeError if it is not
+// of the correct type, or a DomainError if it is outside the universe of the
+// set. Otherwise, it returns nil. Elements that already exist in the set are
+// valid.
+func (s *Set) Validate(elem interface{}) error {
+	if !s.properType(elem) {
+		return &TypeError{s.elementsType, reflect.ValueOf(elem).Type(), "The element is not of the set's type."}
+	}
+
+	if !s.inDomain(elem) {
+		return &DomainError{Elem: elem}
+	}
+
+	return nil
+}
+
+// Complement returns the set of the values of the universe of s that are not
+// elements of s. It is bound to the same universe. If s is not bound to a
+// universe, ErrNoDomain is returned.
+func (s *Set) Complement() (Set, error) {
+	if s.domain == nil {
+		return Set{}, ErrNoDomain
+	}
+
+	c := NewSet()
+	c.elementsType = s.domain.elementsType
+	c.domain = s.domain
+
+	for _, v := range s.domain.values {
+		if _, ok := s.Set[v]; !ok {
+			c.Add(v)
+		}
+	}
+
+	return c, nil
+}
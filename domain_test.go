@@ -0,0 +1,133 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestBind(t *testing.T) {
+	u, _ := NewUniverse(1, 2, 3)
+
+	s := NewSet()
+	s.Add(1)
+	if err := s.Bind(u); err != nil {
+		t.Fatalf("There was an error trying to bind %v.\n%v", s, err)
+	}
+
+	if s.Add(4) || !s.Add(2) {
+		t.Errorf("The set %v does not respect its universe.", s)
+	}
+
+	if _, ok := s.Validate(4).(*DomainError); !ok {
+		t.Errorf("4 was not rejected with a DomainError.")
+	}
+	if _, ok := s.Validate("a").(*TypeError); !ok {
+		t.Errorf("\"a\" was not rejected with a TypeError.")
+	}
+	if err := s.Validate(3); err != nil {
+		t.Errorf("3 was rejected.\n%v", err)
+	}
+
+	outside := CreateSet(4)
+	if _, ok := outside.Bind(u).(*DomainError); !ok || outside.Domain() != nil {
+		t.Errorf("The set %v was bound to a universe it does not fit in.", outside)
+	}
+
+	if _, err := s.Union(outside); err == nil {
+		t.Errorf("The union of %v with %v left the universe.", s, outside)
+	}
+
+	d := Diff(CreateSet(2), outside)
+	if _, ok := s.Apply(d).(*DomainError); !ok || !s.Has(2) || s.Has(4) {
+		t.Errorf("Applying %v to %v did not fail, or changed the set.", d, s)
+	}
+
+	unbound := NewSet()
+	if _, err := unbound.Complement(); err != ErrNoDomain {
+		t.Errorf("The complement of an unbound set did not fail.")
+	}
+}
+
+// subsets returns every subset of the universe u, bound to it.
+func subsets(u *Universe) []Set {
+	var all []Set
+	for mask := 0; mask < 1<<u.Size(); mask++ {
+		s := NewSet()
+		s.Bind(u)
+		for i := 0; i < u.Size(); i++ {
+			if mask&(1<<i) != 0 {
+				s.Add(u.Value(i))
+			}
+		}
+		all = append(all, s)
+	}
+
+	return all
+}
+
+func TestComplementProperties(t *testing.T) {
+	u, _ := NewUniverse(1, 2, 3, 4)
+	full := u.Set()
+	all := subsets(u)
+
+	// ∅' = U and U' = ∅
+	empty, _ := all[0].Complement()
+	if !empty.Equal(full) {
+		t.Errorf("The complement of the empty set is %v, instead of %v.", empty, full)
+	}
+	complement, _ := full.Complement()
+	if !complement.Empty() {
+		t.Errorf("The complement of the universe is %v, instead of the empty set.", complement)
+	}
+
+	for _, s1 := range all {
+		c1, err := s1.Complement()
+		if err != nil {
+			t.Fatalf("There was an error trying to make the complement of %v.\n%v", s1, err)
+		}
+
+		// s1 ∪ s1' = U
+		union, _ := s1.Union(c1)
+		if !union.Equal(full) {
+			t.Errorf("The union of %v and its complement is %v.", s1, union)
+		}
+
+		// s1 ∩ s1' = ∅
+		intersection, _ := s1.Intersection(c1)
+		if !intersection.Empty() {
+			t.Errorf("The intersection of %v and its complement is %v.", s1, intersection)
+		}
+
+		// (s1')' = s1
+		cc, _ := c1.Complement()
+		if !cc.Equal(s1) {
+			t.Errorf("The complement of the complement of %v is %v.", s1, cc)
+		}
+
+		for _, s2 := range all {
+			c2, _ := s2.Complement()
+
+			// (s1 ∪ s2)' = s1' ∩ s2'
+			union, _ := s1.Union(s2)
+			got1, _ := union.Complement()
+			got2, _ := c1.Intersection(c2)
+			if !got1.Equal(got2) {
+				t.Errorf("The complement of the union of %v and %v is %v, instead of %v.", s1, s2, got1, got2)
+			}
+
+			// (s1 ∩ s2)' = s1' ∪ s2'
+			intersection, _ := s1.Intersection(s2)
+			got1, _ = intersection.Complement()
+			got2, _ = c1.Union(c2)
+			if !got1.Equal(got2) {
+				t.Errorf("The complement of the intersection of %v and %v is %v, instead of %v.", s1, s2, got1, got2)
+			}
+
+			// s1 \ s2 = s1 ∩ s2'
+			got1, _ = s1.Difference(s2)
+			got2, _ = s1.Intersection(c2)
+			if !got1.Equal(got2) {
+				t.Errorf("The difference of %v from %v is %v, instead of %v.", s2, s1, got1, got2)
+			}
+		}
+	}
+}
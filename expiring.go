@@ -0,0 +1,235 @@
+package set
+
+import (
+	"reflect"
+	"time"
+)
+
+// Clock tells the current time. ExpiringSet asks its Clock instead of calling
+// time.Now, so that tests can control the passing of time.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the Clock of the wall clock.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// ExpiringSet is a set whose elements expire some time after they are added.
+// Expired elements are ignored by every operation, and their memory is
+// reclaimed by Sweep, which also runs on its own every sweep interval, the
+// next time the set is modified. No goroutines are involved, so, just like
+// Set, ExpiringSet is not thread-safe.
+type ExpiringSet struct {
+	members      map[interface{}]time.Time // The deadline of every element
+	elementsType reflect.Type
+	clock        Clock
+	interval     time.Duration
+	nextSweep    time.Time
+}
+
+// NewExpiringSet allocates memory for a new ExpiringSet, that reads the time
+// from clock and sweeps expired elements every interval. If clock is nil, the
+// wall clock is used. If interval is not positive, expired elements are only
+// reclaimed by explicit calls to Sweep. An ExpiringSet created this way can
+// have elements of varying types.
+func NewExpiringSet(clock Clock, interval time.Duration) (s ExpiringSet) {
+	if clock == nil {
+		clock = systemClock{}
+	}
+
+	s.members = make(map[interface{}]time.Time)
+	s.clock = clock
+	s.interval = interval
+	s.nextSweep = clock.Now().Add(interval)
+
+	return s
+}
+
+// SetType sets the type of the elements the set accepts, with the same rules as
+// Set.SetType.
+func (s *ExpiringSet) SetType(elem interface{}) error {
+	newType := reflect.ValueOf(elem).Type()
+
+	if s.elementsType == nil {
+		s.elementsType = newType
+		return nil
+	}
+
+	return &TypeError{s.elementsType, newType, "Trying to re-set the set's type."}
+}
+
+// properType checks if elem is the same type as ExpiringSet.elementsType.
+func (s *ExpiringSet) properType(elem interface{}) bool {
+	return s.elementsType == nil || reflect.ValueOf(elem).Type() == s.elementsType
+}
+
+// live returns true if the deadline has not passed at now.
+func live(deadline, now time.Time) bool {
+	return now.Before(deadline)
+}
+
+// Add adds elem to the set s for the duration ttl. If the element is alive in
+// the set, if it is not of the correct type or if ttl is not positive, no
+// addition is performed and false is returned; in particular, adding a live
+// element again does not extend its life. Otherwise, it returns true.
+func (s *ExpiringSet) Add(elem interface{}, ttl time.Duration) bool {
+	if !s.properType(elem) || ttl <= 0 {
+		return false
+	}
+
+	now := s.clock.Now()
+	s.sweepIfDue(now)
+
+	if deadline, ok := s.members[elem]; ok && live(deadline, now) {
+		return false
+	}
+
+	s.members[elem] = now.Add(ttl)
+
+	return true
+}
+
+// Has returns true if the element provided is alive in the set, otherwise
+// false.
+func (s *ExpiringSet) Has(elem interface{}) bool {
+	if !s.properType(elem) {
+		return false
+	}
+
+	deadline, ok := s.members[elem]
+
+	return ok && live(deadline, s.clock.Now())
+}
+
+// ExpiresAt returns the time the element provided expires and true, if it is
+// alive in the set, otherwise false.
+func (s *ExpiringSet) ExpiresAt(elem interface{}) (time.Time, bool) {
+	if !s.Has(elem) {
+		return time.Time{}, false
+	}
+
+	return s.members[elem], true
+}
+
+// Remove removes elem from the set s. If the element is not alive in the set,
+// false is returned. Otherwise, it returns true.
+func (s *ExpiringSet) Remove(elem interface{}) bool {
+	if !s.Has(elem) {
+		return false
+	}
+
+	delete(s.members, elem)
+	s.sweepIfDue(s.clock.Now())
+
+	return true
+}
+
+// Length returns the number of live elements in the set s. Expired elements
+// that have not been swept yet are skipped, so it takes linear time.
+func (s *ExpiringSet) Length() int {
+	now := s.clock.Now()
+
+	n := 0
+	for _, deadline := range s.members {
+		if live(deadline, now) {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Empty returns true if the set has no live elements, otherwise false.
+func (s *ExpiringSet) Empty() bool {
+	if s.Length() > 0 {
+		return false
+	}
+
+	return true
+}
+
+// Sweep removes the expired elements from the set s and returns their number.
+func (s *ExpiringSet) Sweep() int {
+	now := s.clock.Now()
+
+	n := 0
+	for elem, deadline := range s.members {
+		if !live(deadline, now) {
+			delete(s.members, elem)
+			n++
+		}
+	}
+
+	s.nextSweep = now.Add(s.interval)
+
+	return n
+}
+
+// sweepIfDue sweeps the set if the sweep interval has passed since the last
+// sweep.
+func (s *ExpiringSet) sweepIfDue(now time.Time) {
+	if s.interval > 0 && !now.Before(s.nextSweep) {
+		s.Sweep()
+	}
+}
+
+// ElementsType returns the type of the elements the set accepts, or nil if it
+// accepts elements of any type.
+func (s *ExpiringSet) ElementsType() reflect.Type {
+	return s.elementsType
+}
+
+// Each calls fn for every live element of the set s, in no particular order,
+// until fn returns false.
+func (s *ExpiringSet) Each(fn func(elem interface{}) bool) {
+	now := s.clock.Now()
+
+	for elem, deadline := range s.members {
+		if live(deadline, now) && !fn(elem) {
+			return
+		}
+	}
+}
+
+// Live returns a Set with the live elements of the set s, and the same type.
+func (s *ExpiringSet) Live() Set {
+	now := s.clock.Now()
+
+	l := NewSet()
+	l.elementsType = s.elementsType
+
+	for elem, deadline := range s.members {
+		if live(deadline, now) {
+			l.Add(elem)
+		}
+	}
+
+	return l
+}
+
+// Union returns the union of the live elements of the set s1 with the set s2.
+func (s1 *ExpiringSet) Union(s2 Set) (Set, error) {
+	l := s1.Live()
+
+	return l.Union(s2)
+}
+
+// Intersection returns the intersection of the live elements of the set s1
+// with the set s2.
+func (s1 *ExpiringSet) Intersection(s2 Set) (Set, error) {
+	l := s1.Live()
+
+	return l.Intersection(s2)
+}
+
+// Difference returns the difference of the set s2 from the live elements of
+// the set s1.
+func (s1 *ExpiringSet) Difference(s2 Set) (Set, error) {
+	l := s1.Live()
+
+	return l.Difference(s2)
+}
@@ -0,0 +1,103 @@
+package set
+
+import (
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func TestExpiringSetAddHas(t *testing.T) {
+	clock := &fakeClock{time.Unix(0, 0)}
+	s := NewExpiringSet(clock, 0)
+	s.SetType("")
+
+	if !s.Add("a", time.Minute) {
+		t.Errorf("\"a\" was not added in the set %v", s)
+	}
+	if s.Add("a", time.Hour) {
+		t.Errorf("\"a\" was added twice in the set %v", s)
+	}
+	if s.Add(1, time.Hour) {
+		t.Errorf("1 was added in the set %v", s)
+	}
+	if s.Add("b", 0) {
+		t.Errorf("\"b\" was added with no time to live in the set %v", s)
+	}
+
+	clock.advance(59 * time.Second)
+	if !s.Has("a") {
+		t.Errorf("\"a\" expired early in the set %v", s)
+	}
+
+	clock.advance(time.Second)
+	if s.Has("a") || s.Length() != 0 || !s.Empty() {
+		t.Errorf("\"a\" did not expire in the set %v", s)
+	}
+
+	if !s.Add("a", time.Minute) {
+		t.Errorf("The expired \"a\" was not added again in the set %v", s)
+	}
+}
+
+func TestExpiringSetSweep(t *testing.T) {
+	clock := &fakeClock{time.Unix(0, 0)}
+	s := NewExpiringSet(clock, time.Minute)
+
+	s.Add(1, time.Second)
+	s.Add(2, time.Hour)
+
+	clock.advance(time.Second)
+	if n := s.Sweep(); n != 1 {
+		t.Errorf("Sweep removed %d elements from the set %v, instead of 1.", n, s)
+	}
+
+	s.Add(3, time.Second)
+	clock.advance(time.Minute)
+	s.Add(4, time.Hour)
+
+	if len(s.members) != 2 {
+		t.Errorf("The set %v was not swept automatically.", s)
+	}
+}
+
+func TestExpiringSetAlgebra(t *testing.T) {
+	clock := &fakeClock{time.Unix(0, 0)}
+	s := NewExpiringSet(clock, 0)
+	s.SetType(1)
+
+	s.Add(1, time.Second)
+	s.Add(2, time.Hour)
+	clock.advance(time.Second)
+
+	s2 := CreateSet(1)
+	s2.Add(3)
+
+	got, err := s.Union(s2)
+	want := CreateSet(1)
+	want.Add(2)
+	want.Add(3)
+	if err != nil || !got.Equal(want) {
+		t.Errorf("The union of %v and %v resulted in %v, instead of %v.", s, s2, got, want)
+	}
+
+	got, err = s.Intersection(s2)
+	if err != nil || !got.Empty() {
+		t.Errorf("The intersection of %v and %v resulted in %v, instead of the empty set.", s, s2, got)
+	}
+
+	got, err = s.Difference(s2)
+	if err != nil || !got.Equal(CreateSet(2)) {
+		t.Errorf("The difference of %v from %v resulted in %v, instead of {2}.", s2, s, got)
+	}
+
+	if _, err := s.Union(CreateSet("a")); err == nil {
+		t.Errorf("The union of %v with a set of strings succeeded.", s)
+	}
+}
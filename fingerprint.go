@@ -0,0 +1,203 @@
+package set
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"reflect"
+)
+
+// Fingerprint is an order-independent 128-bit digest of the elements of a
+// set. Two sets that are Equal always have the same Fingerprint, regardless of
+// the order their elements were added in, and the value is stable across
+// processes for elements that do not contain pointers or channels. Elements of
+// distinct types with the same name and value hash alike, see appendElement.
+type Fingerprint struct {
+	Hi, Lo uint64
+}
+
+// String returns the fingerprint as 32 hexadecimal digits.
+func (f Fingerprint) String() string {
+	return fmt.Sprintf("%016x%016x", f.Hi, f.Lo)
+}
+
+// digest keeps the running fingerprint of a set. It is only maintained after
+// the first call to Fingerprint, so sets that never ask for one do not pay for
+// hashing every element on Add and Remove.
+type digest struct {
+	sum      Fingerprint
+	n        int
+	tracking bool
+}
+
+func (d *digest) add(elem interface{}) {
+	if d == nil || !d.tracking {
+		return
+	}
+
+	h := hashElement(elem)
+	d.sum.Hi += h.Hi
+	d.sum.Lo += h.Lo
+	d.n++
+}
+
+func (d *digest) remove(elem interface{}) {
+	if d == nil || !d.tracking {
+		return
+	}
+
+	h := hashElement(elem)
+	d.sum.Hi -= h.Hi
+	d.sum.Lo -= h.Lo
+	d.n--
+}
+
+// Fingerprint returns the order-independent digest of the elements of the set
+// s. The first call hashes every element; afterwards the digest is updated
+// incrementally by Add and Remove. If the map s.Set was modified directly, the
+// change in its length is detected and the digest is computed again.
+func (s *Set) Fingerprint() Fingerprint {
+	if s.digest != nil && s.digest.tracking && s.digest.n == len(s.Set) {
+		return s.digest.sum
+	}
+
+	var sum Fingerprint
+	for elem := range s.Set {
+		h := hashElement(elem)
+		sum.Hi += h.Hi
+		sum.Lo += h.Lo
+	}
+
+	if s.digest != nil {
+		s.digest.sum = sum
+		s.digest.n = len(s.Set)
+		s.digest.tracking = true
+	}
+
+	return sum
+}
+
+// hashElement returns the 128-bit hash of the canonical encoding of elem.
+func hashElement(elem interface{}) Fingerprint {
+	h := fnv.New128a()
+	h.Write(appendElement(nil, elem))
+	sum := h.Sum(nil)
+
+	return Fingerprint{
+		Hi: mix64(binary.BigEndian.Uint64(sum[:8])),
+		Lo: mix64(binary.BigEndian.Uint64(sum[8:])),
+	}
+}
+
+// mix64 is the finalizer of MurmurHash3. FNV spreads the last bytes of its
+// input poorly, and the fingerprint is a plain sum of the element hashes.
+func mix64(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb34fe1a85a53
+	x ^= x >> 33
+
+	return x
+}
+
+// appendElement appends the canonical encoding of elem to buf. The encoding
+// starts with the name of the element's type, so elements whose types have
+// different names never share an encoding, even if they hold the same value
+// (e.g. int(1) and int64(1)). Distinct types with the same name, such as types
+// declared in different functions of a package, are not told apart. The name
+// is followed by the value itself:
+//
+//	bool                   one byte, 0 or 1
+//	signed integers        the value as a little-endian int64
+//	unsigned integers      the value as a little-endian uint64
+//	floats                 the IEEE 754 bits of the value as float64, with -0 as 0
+//	complex numbers        the real and then the imaginary part, as floats
+//	strings                the length as a uvarint, followed by the bytes
+//	arrays and structs     every element or field, in order
+//	interfaces             a nil marker, or the type and value they hold
+//	pointers and channels  the address they hold, which is process-local
+func appendElement(buf []byte, elem interface{}) []byte {
+	return appendTyped(buf, reflect.ValueOf(elem))
+}
+
+func appendTyped(buf []byte, v reflect.Value) []byte {
+	if !v.IsValid() {
+		return append(buf, 0)
+	}
+
+	buf = append(buf, 1)
+	buf = appendString(buf, typeName(v.Type()))
+
+	return appendValue(buf, v)
+}
+
+func appendValue(buf []byte, v reflect.Value) []byte {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return append(buf, 1)
+		}
+		return append(buf, 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.LittleEndian.AppendUint64(buf, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return binary.LittleEndian.AppendUint64(buf, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return appendFloat(buf, v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		buf = appendFloat(buf, real(c))
+		return appendFloat(buf, imag(c))
+	case reflect.String:
+		return appendString(buf, v.String())
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			buf = appendValue(buf, v.Index(i))
+		}
+		return buf
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			buf = appendValue(buf, v.Field(i))
+		}
+		return buf
+	case reflect.Interface:
+		if v.IsNil() {
+			return append(buf, 0)
+		}
+		return appendTyped(buf, v.Elem())
+	case reflect.Ptr, reflect.Chan, reflect.UnsafePointer:
+		return binary.LittleEndian.AppendUint64(buf, uint64(v.Pointer()))
+	}
+
+	// Maps, slices and functions are not hashable, so they can never be
+	// elements of a set.
+	return buf
+}
+
+func appendFloat(buf []byte, f float64) []byte {
+	// -0 and 0 are the same key in a map, so they must encode the same.
+	if f == 0 {
+		f = 0
+	}
+
+	return binary.LittleEndian.AppendUint64(buf, math.Float64bits(f))
+}
+
+func appendString(buf []byte, s string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(s)))
+
+	return append(buf, s...)
+}
+
+// typeName returns a name for t that is the same in every process. Named types
+// are qualified with their full package path, which is not enough to tell
+// apart types with the same name declared in different functions.
+func typeName(t reflect.Type) string {
+	if t.Name() != "" && t.PkgPath() != "" {
+		return t.PkgPath() + "." + t.Name()
+	}
+
+	return t.String()
+}
@@ -0,0 +1,128 @@
+package set
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFingerprintOrderIndependent(t *testing.T) {
+	s1 := NewSet()
+	s1.Add(1)
+	s1.Add("a")
+	s1.Add(true)
+
+	s2 := NewSet()
+	s2.Add(true)
+	s2.Add("a")
+	s2.Add(1)
+
+	if s1.Fingerprint() != s2.Fingerprint() {
+		t.Errorf("The sets %v and %v are equal, but their fingerprints differ.", s1, s2)
+	}
+
+	empty := NewSet()
+	if empty.Fingerprint() != (Fingerprint{}) {
+		t.Errorf("The fingerprint of the empty set is not zero.")
+	}
+}
+
+func TestFingerprintIncremental(t *testing.T) {
+	s := CreateSet(1)
+	before := s.Fingerprint()
+
+	s.Add(2)
+	s.Add(3)
+	if s.Fingerprint() == before {
+		t.Errorf("The fingerprint of the set %v did not change after adding elements.", s)
+	}
+
+	s.Remove(3)
+	s.Remove(2)
+	if s.Fingerprint() != before {
+		t.Errorf("The fingerprint of the set %v did not return to %v.", s, before)
+	}
+
+	// Writing to the map directly bypasses Add, but must not leave a stale
+	// fingerprint behind.
+	s.Set[4] = exists
+	want := CreateSet(1)
+	want.Add(4)
+	if s.Fingerprint() != want.Fingerprint() {
+		t.Errorf("The fingerprint of the set %v is %v instead of %v.", s, s.Fingerprint(), want.Fingerprint())
+	}
+}
+
+func TestFingerprintTypes(t *testing.T) {
+	type point struct {
+		X, Y int
+	}
+
+	pairs := [][2]interface{}{
+		{1, int64(1)},
+		{1, "1"},
+		{uint(1), 1},
+		{point{1, 2}, point{2, 1}},
+		{[2]interface{}{1, "a"}, [2]interface{}{"a", 1}},
+		{[2]interface{}{nil, 1}, [2]interface{}{1, nil}},
+	}
+
+	for _, p := range pairs {
+		s1 := NewSet()
+		s1.Add(p[0])
+		s2 := NewSet()
+		s2.Add(p[1])
+
+		if s1.Fingerprint() == s2.Fingerprint() {
+			t.Errorf("The sets %v and %v have the same fingerprint.", s1, s2)
+		}
+	}
+
+	s1 := CreateSet(0.0)
+	s2 := CreateSet(math.Copysign(0, -1))
+	if s1.Fingerprint() != s2.Fingerprint() {
+		t.Errorf("The sets %v and %v are equal, but their fingerprints differ.", s1, s2)
+	}
+}
+
+func TestFingerprintStable(t *testing.T) {
+	s := NewSet()
+	s.Add(1)
+	s.Add("set")
+	s.Add(true)
+
+	// The fingerprint must not depend on the process that computed it.
+	want := "b6d0918d7f67345c13c0348debf4f7cb"
+	if got := s.Fingerprint().String(); got != want {
+		t.Errorf("The fingerprint of the set %v is %v instead of %v.", s, got, want)
+	}
+}
+
+// localPoint and otherPoint return values of two distinct types that have the
+// same package path and name, and thus the same canonical encoding.
+func localPoint() interface{} {
+	type point struct{ X, Y int }
+	return point{1, 2}
+}
+
+func otherPoint() interface{} {
+	type point struct{ X, Y int }
+	return point{1, 2}
+}
+
+func TestSameNamedTypes(t *testing.T) {
+	a, b := localPoint(), otherPoint()
+	if a == b {
+		t.Fatalf("The values %v and %v of distinct types are equal.", a, b)
+	}
+
+	if c := NaturalOrder(a, b); c == 0 || c != -NaturalOrder(b, a) {
+		t.Errorf("The values %v and %v of distinct types are not ordered.", a, b)
+	}
+
+	s1, s2 := NewSet(), NewSet()
+	s1.AddAll(a, b)
+	s2.AddAll(b, a)
+	if s1.Freeze() != s2.Freeze() {
+		t.Errorf("The frozen sets of %v and %v differ.", s1, s2)
+	}
+}
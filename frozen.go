@@ -0,0 +1,131 @@
+package set
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// FrozenSet is an immutable set. Unlike Set, it is hashable, so frozen sets can
+// be elements of a Set or keys of a map. Two frozen sets are equal if they have
+// the same elements and the same type.
+type FrozenSet struct {
+	// v holds an array, built with reflect.ArrayOf, of the elements ordered by
+	// NaturalOrder. Its element type is the type of the set, or interface{}
+	// for sets without a type. Comparing frozen sets compares these arrays.
+	v interface{}
+}
+
+// freeze returns the frozen set of elems, which must already be ordered by
+// NaturalOrder.
+func freeze(elemsType reflect.Type, elems []interface{}) FrozenSet {
+	if elemsType == nil {
+		elemsType = interfaceType
+	}
+
+	v := reflect.New(reflect.ArrayOf(len(elems), elemsType)).Elem()
+	for i, elem := range elems {
+		if elem != nil {
+			v.Index(i).Set(reflect.ValueOf(elem))
+		}
+	}
+
+	return FrozenSet{v.Interface()}
+}
+
+// Freeze returns a FrozenSet with the elements and the type of the set s.
+// Elements of s that are not of its type are left out, as in Product.
+func (s *Set) Freeze() FrozenSet {
+	return freeze(s.elementsType, typedElements(*s, NaturalOrder))
+}
+
+// value returns the array that holds the elements of the frozen set f.
+func (f FrozenSet) value() reflect.Value {
+	if f.v == nil {
+		return reflect.ValueOf([0]interface{}{})
+	}
+
+	return reflect.ValueOf(f.v)
+}
+
+// ElementsType returns the type of the elements of the frozen set f, or nil if
+// it has no type.
+func (f FrozenSet) ElementsType() reflect.Type {
+	if t := f.value().Type().Elem(); t != interfaceType {
+		return t
+	}
+
+	return nil
+}
+
+// Length returns the number of elements in the frozen set f.
+func (f FrozenSet) Length() int {
+	return f.value().Len()
+}
+
+// Empty returns true if the frozen set f has no elements.
+func (f FrozenSet) Empty() bool {
+	return f.Length() == 0
+}
+
+// Has returns true if elem is an element of the frozen set f. It takes
+// O(log n) time.
+func (f FrozenSet) Has(elem interface{}) bool {
+	v := f.value()
+	if t := f.ElementsType(); t != nil && reflect.TypeOf(elem) != t {
+		return false
+	}
+
+	i := sort.Search(v.Len(), func(i int) bool {
+		return NaturalOrder(v.Index(i).Interface(), elem) >= 0
+	})
+
+	return i < v.Len() && v.Index(i).Interface() == elem
+}
+
+// Each calls fn for every element of the frozen set f, ordered by NaturalOrder,
+// until fn returns false.
+func (f FrozenSet) Each(fn func(elem interface{}) bool) {
+	v := f.value()
+	for i := 0; i < v.Len(); i++ {
+		if !fn(v.Index(i).Interface()) {
+			return
+		}
+	}
+}
+
+// Elements returns the elements of the frozen set f, ordered by NaturalOrder.
+func (f FrozenSet) Elements() []interface{} {
+	elems := make([]interface{}, 0, f.Length())
+	f.Each(func(elem interface{}) bool {
+		elems = append(elems, elem)
+		return true
+	})
+
+	return elems
+}
+
+// Set returns a new Set with the elements and the type of the frozen set f.
+func (f FrozenSet) Set() Set {
+	s := NewSet()
+	s.elementsType = f.ElementsType()
+
+	f.Each(func(elem interface{}) bool {
+		s.Add(elem)
+		return true
+	})
+
+	return s
+}
+
+// String returns the elements of the frozen set f in the form {a, b, c}.
+func (f FrozenSet) String() string {
+	elems := make([]string, 0, f.Length())
+	f.Each(func(elem interface{}) bool {
+		elems = append(elems, fmt.Sprint(elem))
+		return true
+	})
+
+	return "{" + strings.Join(elems, ", ") + "}"
+}
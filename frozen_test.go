@@ -0,0 +1,54 @@
+package set
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFreeze(t *testing.T) {
+	s1 := CreateSet(3)
+	s1.AddAll(1, 2)
+	s2 := CreateSet(2)
+	s2.AddAll(3, 1)
+
+	f1, f2 := s1.Freeze(), s2.Freeze()
+	if f1 != f2 {
+		t.Errorf("The frozen sets %v and %v are not equal.", f1, f2)
+	}
+
+	byFlags := map[FrozenSet]string{f1: "on"}
+	if byFlags[f2] != "on" {
+		t.Errorf("The frozen set %v is not usable as a map key.", f2)
+	}
+
+	if f1.String() != "{1, 2, 3}" || f1.Length() != 3 || f1.ElementsType() != reflect.TypeOf(0) {
+		t.Errorf("The frozen set %v has the wrong elements or type.", f1)
+	}
+
+	if !f1.Has(2) || f1.Has(4) || f1.Has(int64(2)) {
+		t.Errorf("The frozen set %v has the wrong elements.", f1)
+	}
+
+	if back := f1.Set(); !back.Equal(s1) || !back.SameType(s1) {
+		t.Errorf("The set %v was not recovered from %v.", back, f1)
+	}
+
+	untyped := NewSet()
+	untyped.AddAll(1, 2, 3)
+	if f := untyped.Freeze(); f == f1 || f.ElementsType() != nil || f.Has(int64(2)) || !f.Has(2) {
+		t.Errorf("The frozen set %v of an untyped set is wrong.", f)
+	}
+
+	var zero FrozenSet
+	if !zero.Empty() || zero.Has(1) || zero.String() != "{}" {
+		t.Errorf("The zero frozen set %v is not empty.", zero)
+	}
+
+	late := NewSet()
+	late.Add("a")
+	late.SetType(1)
+	late.Add(2)
+	if f := late.Freeze(); f.Length() != 1 || !f.Has(2) || f.ElementsType() != late.ElementsType() {
+		t.Errorf("The frozen set %v of %v is wrong.", f, late)
+	}
+}
@@ -0,0 +1,141 @@
+package set
+
+import (
+	"reflect"
+)
+
+// The functions passed to the methods below get every element of the set,
+// whatever its type. On sets with elements of varying types they should use a
+// type switch or a "comma ok" type assertion, rather than a plain type
+// assertion that panics on elements of other types. The order the elements
+// are visited in is unspecified.
+
+// Filter returns a new set with the elements of the set s for which pred
+// returns true. It has the type and the universe of s.
+func (s *Set) Filter(pred func(elem interface{}) bool) Set {
+	f := NewSet()
+	f.elementsType = s.elementsType
+	f.domain = s.domain
+
+	for elem := range s.Set {
+		if pred(elem) {
+			f.Add(elem)
+		}
+	}
+
+	return f
+}
+
+// Map returns a new set with the results of fn for every element of the set s.
+// If s has a type and all the results have the same type, the new set gets
+// that type; otherwise it accepts elements of any type. Since the elements of
+// a set must be hashable, if fn returns a value that is not, a TypeError is
+// returned.
+func (s *Set) Map(fn func(elem interface{}) interface{}) (Set, error) {
+	m := NewSet()
+
+	// A nil result has no type, which is a type of its own here: a set of
+	// another type cannot hold it.
+	var resultType reflect.Type
+	first, homogeneous := true, true
+
+	for elem := range s.Set {
+		v := fn(elem)
+
+		if v != nil {
+			if rv := reflect.ValueOf(v); !rv.Comparable() {
+				return Set{}, &TypeError{s.elementsType, rv.Type(), "The element is not hashable."}
+			}
+		}
+
+		t := reflect.TypeOf(v)
+		if first {
+			resultType, first = t, false
+		} else if t != resultType {
+			homogeneous = false
+		}
+
+		m.Add(v)
+	}
+
+	if s.elementsType != nil && homogeneous {
+		m.elementsType = resultType
+	}
+
+	return m, nil
+}
+
+// Partition splits the set s in two new sets: the elements for which pred
+// returns true and the rest. Both have the type and the universe of s.
+func (s *Set) Partition(pred func(elem interface{}) bool) (in, out Set) {
+	in, out = NewSet(), NewSet()
+	in.elementsType, out.elementsType = s.elementsType, s.elementsType
+	in.domain, out.domain = s.domain, s.domain
+
+	for elem := range s.Set {
+		if pred(elem) {
+			in.Add(elem)
+		} else {
+			out.Add(elem)
+		}
+	}
+
+	return in, out
+}
+
+// Reduce combines the elements of the set s into a single value, by calling fn
+// with the value accumulated so far, starting from init, and every element.
+// Since the order of the elements is unspecified, fn should be commutative.
+func (s *Set) Reduce(fn func(acc, elem interface{}) interface{}, init interface{}) interface{} {
+	acc := init
+	for elem := range s.Set {
+		acc = fn(acc, elem)
+	}
+
+	return acc
+}
+
+// Any returns true if pred returns true for at least one element of the set s,
+// otherwise false.
+func (s *Set) Any(pred func(elem interface{}) bool) bool {
+	_, found := s.Find(pred)
+
+	return found
+}
+
+// All returns true if pred returns true for every element of the set s, which
+// is the case for the empty set, otherwise false.
+func (s *Set) All(pred func(elem interface{}) bool) bool {
+	for elem := range s.Set {
+		if !pred(elem) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Count returns the number of elements of the set s for which pred returns
+// true.
+func (s *Set) Count(pred func(elem interface{}) bool) int {
+	n := 0
+	for elem := range s.Set {
+		if pred(elem) {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Find returns an element of the set s for which pred returns true and true, or
+// false if there is no such element.
+func (s *Set) Find(pred func(elem interface{}) bool) (interface{}, bool) {
+	for elem := range s.Set {
+		if pred(elem) {
+			return elem, true
+		}
+	}
+
+	return nil, false
+}
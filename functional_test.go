@@ -0,0 +1,102 @@
+package set
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func isEven(elem interface{}) bool {
+	i, ok := elem.(int)
+	return ok && i%2 == 0
+}
+
+func TestFilterPartition(t *testing.T) {
+	s := CreateSet(1)
+	s.AddAll(2, 3, 4)
+
+	got := s.Filter(isEven)
+	want := CreateSet(2)
+	want.Add(4)
+	if !got.Equal(want) || !got.SameType(s) {
+		t.Errorf("Filtering %v resulted in %v, instead of %v.", s, got, want)
+	}
+
+	in, out := s.Partition(isEven)
+	want2 := CreateSet(1)
+	want2.Add(3)
+	if !in.Equal(want) || !out.Equal(want2) {
+		t.Errorf("Partitioning %v resulted in %v and %v.", s, in, out)
+	}
+}
+
+func TestMap(t *testing.T) {
+	s := CreateSet(1)
+	s.AddAll(2, 3)
+
+	got, err := s.Map(func(elem interface{}) interface{} { return strconv.Itoa(elem.(int)) })
+	if err != nil || got.ElementsType() != reflect.TypeOf("") || !got.Has("2") {
+		t.Errorf("Mapping %v to strings resulted in %v.", s, got)
+	}
+
+	got, err = s.Map(func(elem interface{}) interface{} { return elem.(int) % 2 })
+	if err != nil || got.Length() != 2 {
+		t.Errorf("Mapping %v to remainders resulted in %v.", s, got)
+	}
+
+	got, err = s.Map(func(elem interface{}) interface{} {
+		if elem.(int) == 1 {
+			return "1"
+		}
+		return elem
+	})
+	if err != nil || got.ElementsType() != nil || got.Length() != 3 {
+		t.Errorf("Mapping %v to mixed types resulted in %v.", s, got)
+	}
+
+	got, err = s.Map(func(elem interface{}) interface{} {
+		if elem.(int) == 1 {
+			return nil
+		}
+		return "x"
+	})
+	if err != nil || got.ElementsType() != nil || !got.Has(nil) || got.Length() != 2 {
+		t.Errorf("Mapping %v to nil and strings resulted in %v.", s, got)
+	}
+
+	if _, err := s.Map(func(elem interface{}) interface{} { return []int{1} }); err == nil {
+		t.Errorf("Mapping %v to slices succeeded.", s)
+	}
+
+	if _, err := s.Map(func(elem interface{}) interface{} { return [1]interface{}{[]int{1}} }); err == nil {
+		t.Errorf("Mapping %v to arrays of slices succeeded.", s)
+	}
+}
+
+func TestPredicates(t *testing.T) {
+	s := NewSet()
+	s.AddAll(1, 2, "a", true)
+
+	if !s.Any(isEven) || s.All(isEven) || s.Count(isEven) != 1 {
+		t.Errorf("The predicates on %v are wrong.", s)
+	}
+
+	if elem, ok := s.Find(isEven); !ok || elem != 2 {
+		t.Errorf("The even element of %v is %v, instead of 2.", s, elem)
+	}
+
+	empty := NewSet()
+	if !empty.All(isEven) || empty.Any(isEven) {
+		t.Errorf("The predicates on the empty set are wrong.")
+	}
+
+	sum := s.Reduce(func(acc, elem interface{}) interface{} {
+		if i, ok := elem.(int); ok {
+			return acc.(int) + i
+		}
+		return acc
+	}, 0)
+	if sum != 3 {
+		t.Errorf("The sum of the ints of %v is %v, instead of 3.", s, sum)
+	}
+}
@@ -0,0 +1,84 @@
+package set
+
+import (
+	"reflect"
+)
+
+// GroupBy splits the set s into groups of the elements with the same key, as
+// returned by keyFn. The keys must be hashable. Every group has the type of s;
+// if s has none, a group whose elements all have the same type gets that
+// type.
+func (s *Set) GroupBy(keyFn func(elem interface{}) interface{}) map[interface{}]Set {
+	groups := make(map[interface{}]Set)
+
+	for elem := range s.Set {
+		key := keyFn(elem)
+
+		g, ok := groups[key]
+		if !ok {
+			g = NewSet()
+			g.domain = s.domain
+		}
+
+		g.Add(elem)
+		groups[key] = g
+	}
+
+	for key, g := range groups {
+		g.elementsType = s.elementsType
+		if g.elementsType == nil {
+			g.elementsType = commonType(g)
+		}
+		groups[key] = g
+	}
+
+	return groups
+}
+
+// commonType returns the type all the elements of s have, or nil if they have
+// different types.
+func commonType(s Set) reflect.Type {
+	var t reflect.Type
+	for elem := range s.Set {
+		et := reflect.TypeOf(elem)
+		if t != nil && et != t {
+			return nil
+		}
+		t = et
+	}
+
+	return t
+}
+
+// SplitByType splits the set s into sets of the elements of each type, every
+// one of them with that type.
+func (s *Set) SplitByType() map[reflect.Type]Set {
+	groups := make(map[reflect.Type]Set)
+
+	for elem := range s.Set {
+		t := reflect.TypeOf(elem)
+
+		g, ok := groups[t]
+		if !ok {
+			g = NewSet()
+			g.elementsType = t
+			g.domain = s.domain
+			groups[t] = g
+		}
+
+		g.Add(elem)
+	}
+
+	return groups
+}
+
+// TypeHistogram returns the number of elements of each type in the set s.
+func (s *Set) TypeHistogram() map[reflect.Type]int {
+	histogram := make(map[reflect.Type]int)
+
+	for elem := range s.Set {
+		histogram[reflect.TypeOf(elem)]++
+	}
+
+	return histogram
+}
@@ -0,0 +1,73 @@
+package set
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func mixedSet() Set {
+	s := NewSet()
+	s.AddAll(1, 2, 3, true, "a", "b")
+
+	return s
+}
+
+func TestGroupBy(t *testing.T) {
+	s := mixedSet()
+
+	groups := s.GroupBy(func(elem interface{}) interface{} {
+		if i, ok := elem.(int); ok {
+			return i % 2
+		}
+		return "other"
+	})
+
+	if len(groups) != 3 {
+		t.Fatalf("Grouping %v resulted in %d groups, instead of 3.", s, len(groups))
+	}
+
+	odd := groups[1]
+	if odd.Length() != 2 || odd.ElementsType() != reflect.TypeOf(0) {
+		t.Errorf("The odd group of %v is %v.", s, odd)
+	}
+
+	other := groups["other"]
+	if other.Length() != 3 || other.ElementsType() != nil {
+		t.Errorf("The other group of %v is %v.", s, other)
+	}
+
+	typed := CreateSet(1)
+	typed.Add(2)
+	for _, g := range typed.GroupBy(func(elem interface{}) interface{} { return fmt.Sprint(elem) }) {
+		if !g.SameType(typed) {
+			t.Errorf("The group %v does not have the type of %v.", g, typed)
+		}
+	}
+}
+
+func TestSplitByType(t *testing.T) {
+	s := mixedSet()
+	split := s.SplitByType()
+
+	strings := split[reflect.TypeOf("")]
+	if strings.Length() != 2 || strings.Add(1) {
+		t.Errorf("The strings of %v are %v.", s, strings)
+	}
+
+	histogram := s.TypeHistogram()
+	want := map[reflect.Type]int{reflect.TypeOf(0): 3, reflect.TypeOf(true): 1, reflect.TypeOf(""): 2}
+	if !reflect.DeepEqual(histogram, want) {
+		t.Errorf("The type histogram of %v is %v, instead of %v.", s, histogram, want)
+	}
+
+	union := NewSet()
+	for _, part := range split {
+		for elem := range part.Set {
+			union.Add(elem)
+		}
+	}
+	if !union.Equal(s) {
+		t.Errorf("The parts of %v do not add up to it.", s)
+	}
+}
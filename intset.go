@@ -0,0 +1,841 @@
+package set
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"reflect"
+	"sort"
+)
+
+// IntSet is a compressed set of uint32 elements, in the style of Roaring
+// bitmaps. The elements are grouped by their upper 16 bits into chunks of
+// 65536 values, and the lower 16 bits of every chunk are stored in whichever
+// container is smallest for them: a sorted array for sparse chunks, a bitmap
+// for dense ones, or a list of runs for consecutive values (see RunOptimize).
+type IntSet struct {
+	keys         []uint16 // The upper 16 bits of every chunk, sorted
+	containers   []container
+	elementsType reflect.Type // The type of the Set it was converted from
+}
+
+// arrayMax is the largest cardinality an array container may have. Beyond it,
+// a bitmap, which takes 8KB, is smaller.
+const arrayMax = 4096
+
+// container holds the lower 16 bits of the elements of a chunk. The methods
+// that modify it return the container to use from then on, which may be of
+// another kind.
+type container interface {
+	add(x uint16) (container, bool)
+	remove(x uint16) (container, bool)
+	has(x uint16) bool
+	cardinality() int
+	// rank returns the number of elements less than or equal to x.
+	rank(x uint16) int
+	// each calls fn for every element, in order, until fn returns false,
+	// and returns false if it was stopped.
+	each(fn func(x uint16) bool) bool
+}
+
+// arrayContainer is a sorted array of the elements of a chunk.
+type arrayContainer []uint16
+
+func (a arrayContainer) search(x uint16) int {
+	return sort.Search(len(a), func(i int) bool { return a[i] >= x })
+}
+
+func (a arrayContainer) add(x uint16) (container, bool) {
+	i := a.search(x)
+	if i < len(a) && a[i] == x {
+		return a, false
+	}
+
+	if len(a) >= arrayMax {
+		b := a.toBitmap()
+		b.set(x)
+		return b, true
+	}
+
+	a = append(a, 0)
+	copy(a[i+1:], a[i:])
+	a[i] = x
+
+	return a, true
+}
+
+func (a arrayContainer) remove(x uint16) (container, bool) {
+	i := a.search(x)
+	if i == len(a) || a[i] != x {
+		return a, false
+	}
+
+	return append(a[:i], a[i+1:]...), true
+}
+
+func (a arrayContainer) has(x uint16) bool {
+	i := a.search(x)
+	return i < len(a) && a[i] == x
+}
+
+func (a arrayContainer) cardinality() int {
+	return len(a)
+}
+
+func (a arrayContainer) rank(x uint16) int {
+	return sort.Search(len(a), func(i int) bool { return a[i] > x })
+}
+
+func (a arrayContainer) each(fn func(x uint16) bool) bool {
+	for _, x := range a {
+		if !fn(x) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (a arrayContainer) toBitmap() *bitmapContainer {
+	b := &bitmapContainer{}
+	for _, x := range a {
+		b.set(x)
+	}
+
+	return b
+}
+
+// bitmapContainer is a bitmap of the 65536 values of a chunk.
+type bitmapContainer struct {
+	words [1024]uint64
+	card  int
+}
+
+func (b *bitmapContainer) set(x uint16) bool {
+	w, mask := x/64, uint64(1)<<(x%64)
+	if b.words[w]&mask != 0 {
+		return false
+	}
+
+	b.words[w] |= mask
+	b.card++
+
+	return true
+}
+
+func (b *bitmapContainer) add(x uint16) (container, bool) {
+	return b, b.set(x)
+}
+
+func (b *bitmapContainer) remove(x uint16) (container, bool) {
+	w, mask := x/64, uint64(1)<<(x%64)
+	if b.words[w]&mask == 0 {
+		return b, false
+	}
+
+	b.words[w] &^= mask
+	b.card--
+
+	if b.card <= arrayMax {
+		return b.toArray(), true
+	}
+
+	return b, true
+}
+
+func (b *bitmapContainer) has(x uint16) bool {
+	return b.words[x/64]&(uint64(1)<<(x%64)) != 0
+}
+
+func (b *bitmapContainer) cardinality() int {
+	return b.card
+}
+
+func (b *bitmapContainer) rank(x uint16) int {
+	n := 0
+	for w := 0; w < int(x/64); w++ {
+		n += bits.OnesCount64(b.words[w])
+	}
+
+	// The mask keeps the bits up to and including x.
+	mask := ^uint64(0) >> (63 - x%64)
+
+	return n + bits.OnesCount64(b.words[x/64]&mask)
+}
+
+func (b *bitmapContainer) each(fn func(x uint16) bool) bool {
+	for w, word := range b.words {
+		for word != 0 {
+			t := bits.TrailingZeros64(word)
+			if !fn(uint16(w*64 + t)) {
+				return false
+			}
+			word &= word - 1
+		}
+	}
+
+	return true
+}
+
+func (b *bitmapContainer) toArray() arrayContainer {
+	a := make(arrayContainer, 0, b.card)
+	b.each(func(x uint16) bool {
+		a = append(a, x)
+		return true
+	})
+
+	return a
+}
+
+// interval is a run of consecutive values, from start to start+length, both
+// inclusive.
+type interval struct {
+	start, length uint16
+}
+
+func (r interval) last() uint16 {
+	return r.start + r.length
+}
+
+// runContainer is a sorted list of non-overlapping, non-adjacent runs. It is
+// only created by RunOptimize; modifying it turns it into an array or a bitmap.
+type runContainer []interval
+
+func (r runContainer) add(x uint16) (container, bool) {
+	if r.has(x) {
+		return r, false
+	}
+
+	return r.expand().add(x)
+}
+
+func (r runContainer) remove(x uint16) (container, bool) {
+	if !r.has(x) {
+		return r, false
+	}
+
+	return r.expand().remove(x)
+}
+
+func (r runContainer) has(x uint16) bool {
+	i := sort.Search(len(r), func(i int) bool { return r[i].last() >= x })
+	return i < len(r) && r[i].start <= x
+}
+
+func (r runContainer) cardinality() int {
+	n := 0
+	for _, run := range r {
+		n += int(run.length) + 1
+	}
+
+	return n
+}
+
+func (r runContainer) rank(x uint16) int {
+	n := 0
+	for _, run := range r {
+		if run.start > x {
+			break
+		}
+		if run.last() >= x {
+			return n + int(x-run.start) + 1
+		}
+		n += int(run.length) + 1
+	}
+
+	return n
+}
+
+func (r runContainer) each(fn func(x uint16) bool) bool {
+	for _, run := range r {
+		for x := int(run.start); x <= int(run.last()); x++ {
+			if !fn(uint16(x)) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// expand turns the runs into an array or a bitmap, whichever fits.
+func (r runContainer) expand() container {
+	if r.cardinality() > arrayMax {
+		b := &bitmapContainer{}
+		r.each(func(x uint16) bool {
+			b.set(x)
+			return true
+		})
+		return b
+	}
+
+	a := make(arrayContainer, 0, r.cardinality())
+	r.each(func(x uint16) bool {
+		a = append(a, x)
+		return true
+	})
+
+	return a
+}
+
+// toRuns returns the runs of the elements of c.
+func toRuns(c container) runContainer {
+	var r runContainer
+	c.each(func(x uint16) bool {
+		if n := len(r); n > 0 && r[n-1].last()+1 == x {
+			r[n-1].length++
+		} else {
+			r = append(r, interval{x, 0})
+		}
+		return true
+	})
+
+	return r
+}
+
+// size returns the number of bytes the elements of c take.
+func size(c container) int {
+	switch c := c.(type) {
+	case arrayContainer:
+		return 2 * len(c)
+	case runContainer:
+		return 4 * len(c)
+	}
+
+	return 8 * 1024
+}
+
+// NewIntSet allocates memory for a new, empty IntSet.
+func NewIntSet() (s IntSet) {
+	s.elementsType = reflect.TypeOf(uint32(0))
+
+	return s
+}
+
+// find returns the position of the chunk with the given key, or the position
+// it should be inserted at and false.
+func (s *IntSet) find(key uint16) (int, bool) {
+	i := sort.Search(len(s.keys), func(i int) bool { return s.keys[i] >= key })
+
+	return i, i < len(s.keys) && s.keys[i] == key
+}
+
+// Add adds x to the set s. If it already exists in the set, false is returned.
+// Otherwise, it returns true.
+func (s *IntSet) Add(x uint32) bool {
+	key, low := uint16(x>>16), uint16(x)
+
+	i, ok := s.find(key)
+	if !ok {
+		s.keys = append(s.keys, 0)
+		copy(s.keys[i+1:], s.keys[i:])
+		s.keys[i] = key
+
+		s.containers = append(s.containers, nil)
+		copy(s.containers[i+1:], s.containers[i:])
+		s.containers[i] = arrayContainer{low}
+
+		return true
+	}
+
+	var added bool
+	s.containers[i], added = s.containers[i].add(low)
+
+	return added
+}
+
+// Remove removes x from the set s. If it does not exist in the set, false is
+// returned. Otherwise, it returns true.
+func (s *IntSet) Remove(x uint32) bool {
+	i, ok := s.find(uint16(x >> 16))
+	if !ok {
+		return false
+	}
+
+	var removed bool
+	s.containers[i], removed = s.containers[i].remove(uint16(x))
+
+	if s.containers[i].cardinality() == 0 {
+		s.keys = append(s.keys[:i], s.keys[i+1:]...)
+		s.containers = append(s.containers[:i], s.containers[i+1:]...)
+	}
+
+	return removed
+}
+
+// Has returns true if x exists in the set, otherwise false.
+func (s *IntSet) Has(x uint32) bool {
+	i, ok := s.find(uint16(x >> 16))
+
+	return ok && s.containers[i].has(uint16(x))
+}
+
+// Length returns the number of elements in the set s.
+func (s *IntSet) Length() int {
+	n := 0
+	for _, c := range s.containers {
+		n += c.cardinality()
+	}
+
+	return n
+}
+
+// Empty returns true if the set is empty, otherwise false.
+func (s *IntSet) Empty() bool {
+	return len(s.containers) == 0
+}
+
+// Rank returns the number of elements of the set s that are less than or
+// equal to x.
+func (s *IntSet) Rank(x uint32) int {
+	i, ok := s.find(uint16(x >> 16))
+
+	n := 0
+	for _, c := range s.containers[:i] {
+		n += c.cardinality()
+	}
+
+	if ok {
+		n += s.containers[i].rank(uint16(x))
+	}
+
+	return n
+}
+
+// Each calls fn for every element of the set s, in increasing order, until fn
+// returns false.
+func (s *IntSet) Each(fn func(x uint32) bool) {
+	for i, c := range s.containers {
+		high := uint32(s.keys[i]) << 16
+		if !c.each(func(low uint16) bool { return fn(high | uint32(low)) }) {
+			return
+		}
+	}
+}
+
+// RunOptimize turns every container of the set s that is smaller as a list of
+// runs into one. It pays off for sets with long stretches of consecutive
+// elements.
+func (s *IntSet) RunOptimize() {
+	for i, c := range s.containers {
+		if r := toRuns(c); size(r) < size(c) {
+			s.containers[i] = r
+		}
+	}
+}
+
+// normalize returns c as an array or a bitmap, whichever fits its cardinality,
+// or nil if it is empty.
+func normalize(c container) container {
+	if c.cardinality() == 0 {
+		return nil
+	}
+
+	if b, ok := c.(*bitmapContainer); ok && b.card <= arrayMax {
+		return b.toArray()
+	}
+
+	return c
+}
+
+// bitmapOf returns the elements of c as a bitmap. A bitmap container is
+// returned as is.
+func bitmapOf(c container) *bitmapContainer {
+	if b, ok := c.(*bitmapContainer); ok {
+		return b
+	}
+
+	b := &bitmapContainer{}
+	c.each(func(x uint16) bool {
+		b.set(x)
+		return true
+	})
+
+	return b
+}
+
+// combine returns a new container with the elements of the chunk of c1 and c2
+// that are kept by op. Either container may be nil, if the chunk only exists
+// in the other set.
+func combine(c1, c2 container, op func(w1, w2 uint64) uint64) container {
+	if c1 == nil {
+		c1 = arrayContainer(nil)
+	}
+	if c2 == nil {
+		c2 = arrayContainer(nil)
+	}
+
+	a1, ok1 := c1.(arrayContainer)
+	a2, ok2 := c2.(arrayContainer)
+	if ok1 && ok2 {
+		return combineArrays(a1, a2, op)
+	}
+
+	b1, b2 := bitmapOf(c1), bitmapOf(c2)
+	b := &bitmapContainer{}
+	for w := range b.words {
+		b.words[w] = op(b1.words[w], b2.words[w])
+		b.card += bits.OnesCount64(b.words[w])
+	}
+
+	return normalize(b)
+}
+
+// combineArrays is combine for two sparse chunks. It merges the arrays,
+// applying op to the single bits that tell if an element is in each of them.
+func combineArrays(a1, a2 arrayContainer, op func(w1, w2 uint64) uint64) container {
+	var a arrayContainer
+
+	i, j := 0, 0
+	for i < len(a1) || j < len(a2) {
+		var x uint16
+		var in1, in2 uint64
+
+		switch {
+		case j == len(a2) || (i < len(a1) && a1[i] < a2[j]):
+			x, in1 = a1[i], 1
+			i++
+		case i == len(a1) || a1[i] > a2[j]:
+			x, in2 = a2[j], 1
+			j++
+		default:
+			x, in1, in2 = a1[i], 1, 1
+			i++
+			j++
+		}
+
+		if op(in1, in2)&1 != 0 {
+			a = append(a, x)
+		}
+	}
+
+	if len(a) > arrayMax {
+		return a.toBitmap()
+	}
+	if len(a) == 0 {
+		return nil
+	}
+
+	return a
+}
+
+// combine walks the chunks of both sets in order and builds a new set from
+// the result of op on every pair of chunks. Chunks that exist in only one of
+// the sets are passed to op only if left or right, respectively, is set.
+func (s1 *IntSet) combine(s2 IntSet, left, right bool, op func(w1, w2 uint64) uint64) IntSet {
+	s := NewIntSet()
+	s.elementsType = s1.elementsType
+
+	push := func(key uint16, c container) {
+		if c != nil {
+			s.keys = append(s.keys, key)
+			s.containers = append(s.containers, c)
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(s1.keys) || j < len(s2.keys) {
+		switch {
+		case j == len(s2.keys) || (i < len(s1.keys) && s1.keys[i] < s2.keys[j]):
+			if left {
+				push(s1.keys[i], clone(s1.containers[i]))
+			}
+			i++
+		case i == len(s1.keys) || s1.keys[i] > s2.keys[j]:
+			if right {
+				push(s2.keys[j], clone(s2.containers[j]))
+			}
+			j++
+		default:
+			push(s1.keys[i], combine(s1.containers[i], s2.containers[j], op))
+			i++
+			j++
+		}
+	}
+
+	return s
+}
+
+// clone returns a copy of c that shares no memory with it.
+func clone(c container) container {
+	switch c := c.(type) {
+	case arrayContainer:
+		return append(arrayContainer(nil), c...)
+	case runContainer:
+		return append(runContainer(nil), c...)
+	case *bitmapContainer:
+		b := *c
+		return &b
+	}
+
+	return nil
+}
+
+// Union returns the union of the two sets.
+func (s1 *IntSet) Union(s2 IntSet) IntSet {
+	return s1.combine(s2, true, true, func(w1, w2 uint64) uint64 { return w1 | w2 })
+}
+
+// Intersection returns the intersection of the two sets.
+func (s1 *IntSet) Intersection(s2 IntSet) IntSet {
+	return s1.combine(s2, false, false, func(w1, w2 uint64) uint64 { return w1 & w2 })
+}
+
+// Difference returns the difference s1\s2.
+func (s1 *IntSet) Difference(s2 IntSet) IntSet {
+	return s1.combine(s2, true, false, func(w1, w2 uint64) uint64 { return w1 &^ w2 })
+}
+
+// Equal returns true if the two sets have the very same elements, otherwise
+// false.
+func (s1 *IntSet) Equal(s2 IntSet) bool {
+	if len(s1.keys) != len(s2.keys) || s1.Length() != s2.Length() {
+		return false
+	}
+
+	equal := true
+	s1.Each(func(x uint32) bool {
+		equal = s2.Has(x)
+		return equal
+	})
+
+	return equal
+}
+
+// IntSetFromSet converts the Set s to an IntSet. The type of s must be an
+// integer kind and all of its elements must be of that type and fit in a
+// uint32, otherwise an error is returned. The type of s is remembered, to be
+// restored by ToSet.
+func IntSetFromSet(s Set) (IntSet, error) {
+	if !isInteger(s.elementsType) {
+		return IntSet{}, &TypeError{s.elementsType, reflect.TypeOf(uint32(0)),
+			"The set's type is not an integer kind."}
+	}
+
+	is := NewIntSet()
+	is.elementsType = s.elementsType
+
+	for elem := range s.Set {
+		// Elements added before the type of s was set may be of any type.
+		if t := reflect.TypeOf(elem); t != s.elementsType {
+			return IntSet{}, &TypeError{s.elementsType, t,
+				"The element's type does not match the set's."}
+		}
+
+		v := reflect.ValueOf(elem)
+
+		var x uint64
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if v.Int() < 0 {
+				return IntSet{}, fmt.Errorf("set: %d does not fit in a uint32", v.Int())
+			}
+			x = uint64(v.Int())
+		default:
+			x = v.Uint()
+		}
+
+		if x > math.MaxUint32 {
+			return IntSet{}, fmt.Errorf("set: %d does not fit in a uint32", x)
+		}
+
+		is.Add(uint32(x))
+	}
+
+	return is, nil
+}
+
+// ToSet converts the set s to a Set with the type of the Set it was converted
+// from, or uint32 if it was not converted from one. If an element does not fit
+// in that type, an error is returned.
+func (s *IntSet) ToSet() (Set, error) {
+	t := s.elementsType
+	if t == nil {
+		t = reflect.TypeOf(uint32(0))
+	}
+
+	set := NewSet()
+	set.elementsType = t
+
+	var err error
+	s.Each(func(x uint32) bool {
+		v := reflect.New(t).Elem()
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if v.OverflowInt(int64(x)) {
+				err = fmt.Errorf("set: %d does not fit in a %s", x, t)
+				return false
+			}
+			v.SetInt(int64(x))
+		default:
+			if v.OverflowUint(uint64(x)) {
+				err = fmt.Errorf("set: %d does not fit in a %s", x, t)
+				return false
+			}
+			v.SetUint(uint64(x))
+		}
+
+		set.Add(v.Interface())
+		return true
+	})
+
+	if err != nil {
+		return Set{}, err
+	}
+
+	return set, nil
+}
+
+// isInteger returns true if t is of an integer kind.
+func isInteger(t reflect.Type) bool {
+	if t == nil {
+		return false
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+
+	return false
+}
+
+// The serialization format of an IntSet is, in little-endian order:
+//
+//	magic                     "ISET", 4 bytes
+//	number of containers      uint32
+//	for every container:
+//	    key                   uint16, the upper 16 bits of its elements
+//	    kind                  uint8, one of the container kinds below
+//	    count                 uint32, of elements for arrays, of runs for runs
+//	    payload               count uint16s for arrays, 1024 uint64s for
+//	                          bitmaps, count pairs of uint16 (start, length)
+//	                          for runs
+//
+// The type of the Set the IntSet was converted from is not included.
+const intSetMagic = "ISET"
+
+const (
+	kindArray uint8 = iota
+	kindBitmap
+	kindRun
+)
+
+var errIntSetFormat = errors.New("set: invalid IntSet encoding")
+
+// MarshalBinary encodes the set s in a portable binary format.
+func (s IntSet) MarshalBinary() ([]byte, error) {
+	buf := []byte(intSetMagic)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(s.keys)))
+
+	for i, c := range s.containers {
+		buf = binary.LittleEndian.AppendUint16(buf, s.keys[i])
+
+		switch c := c.(type) {
+		case arrayContainer:
+			buf = append(buf, kindArray)
+			buf = binary.LittleEndian.AppendUint32(buf, uint32(len(c)))
+			for _, x := range c {
+				buf = binary.LittleEndian.AppendUint16(buf, x)
+			}
+		case *bitmapContainer:
+			buf = append(buf, kindBitmap)
+			buf = binary.LittleEndian.AppendUint32(buf, uint32(c.card))
+			for _, w := range c.words {
+				buf = binary.LittleEndian.AppendUint64(buf, w)
+			}
+		case runContainer:
+			buf = append(buf, kindRun)
+			buf = binary.LittleEndian.AppendUint32(buf, uint32(len(c)))
+			for _, r := range c {
+				buf = binary.LittleEndian.AppendUint16(buf, r.start)
+				buf = binary.LittleEndian.AppendUint16(buf, r.length)
+			}
+		}
+	}
+
+	return buf, nil
+}
+
+// UnmarshalBinary decodes a set that was encoded with MarshalBinary.
+func (s *IntSet) UnmarshalBinary(data []byte) error {
+	if len(data) < 8 || string(data[:4]) != intSetMagic {
+		return errIntSetFormat
+	}
+
+	n := binary.LittleEndian.Uint32(data[4:])
+	data = data[8:]
+
+	is := NewIntSet()
+	for ; n > 0; n-- {
+		if len(data) < 7 {
+			return errIntSetFormat
+		}
+
+		key, kind := binary.LittleEndian.Uint16(data), data[2]
+		count := int(binary.LittleEndian.Uint32(data[3:]))
+		data = data[7:]
+
+		if len(is.keys) > 0 && key <= is.keys[len(is.keys)-1] {
+			return errIntSetFormat
+		}
+
+		var c container
+		switch kind {
+		case kindArray:
+			if count == 0 || count > arrayMax || len(data) < 2*count {
+				return errIntSetFormat
+			}
+			a := make(arrayContainer, count)
+			for i := range a {
+				a[i] = binary.LittleEndian.Uint16(data[2*i:])
+				if i > 0 && a[i] <= a[i-1] {
+					return errIntSetFormat
+				}
+			}
+			c, data = a, data[2*count:]
+		case kindBitmap:
+			if len(data) < 8*1024 {
+				return errIntSetFormat
+			}
+			b := &bitmapContainer{}
+			for i := range b.words {
+				b.words[i] = binary.LittleEndian.Uint64(data[8*i:])
+				b.card += bits.OnesCount64(b.words[i])
+			}
+			if b.card != count || b.card == 0 {
+				return errIntSetFormat
+			}
+			c, data = b, data[8*1024:]
+		case kindRun:
+			if count == 0 || len(data) < 4*count {
+				return errIntSetFormat
+			}
+			r := make(runContainer, count)
+			for i := range r {
+				r[i] = interval{binary.LittleEndian.Uint16(data[4*i:]), binary.LittleEndian.Uint16(data[4*i+2:])}
+				if int(r[i].start)+int(r[i].length) > math.MaxUint16 ||
+					(i > 0 && int(r[i].start) <= int(r[i-1].last())+1) {
+					return errIntSetFormat
+				}
+			}
+			c, data = r, data[4*count:]
+		default:
+			return errIntSetFormat
+		}
+
+		is.keys = append(is.keys, key)
+		is.containers = append(is.containers, c)
+	}
+
+	if len(data) != 0 {
+		return errIntSetFormat
+	}
+
+	*s = is
+
+	return nil
+}
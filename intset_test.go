@@ -0,0 +1,184 @@
+package set
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// intSetOf returns an IntSet and a Set of uint32 with the same elements.
+func intSetOf(elems []uint32) (IntSet, Set) {
+	is := NewIntSet()
+	s := CreateSet(uint32(0))
+	s.Remove(uint32(0))
+
+	for _, x := range elems {
+		is.Add(x)
+		s.Add(x)
+	}
+
+	return is, s
+}
+
+// randomElements returns elements that fill sparse, dense and consecutive
+// chunks, so that every kind of container is exercised.
+func randomElements(r *rand.Rand) []uint32 {
+	var elems []uint32
+	for i := 0; i < 300; i++ {
+		elems = append(elems, r.Uint32()%(1<<20))
+	}
+	for i := 0; i < 10000; i++ {
+		elems = append(elems, 1<<20+uint32(r.Intn(1<<16)))
+	}
+	for i := uint32(0); i < 5000; i++ {
+		elems = append(elems, 2<<20+i)
+	}
+
+	return elems
+}
+
+func sameElements(t *testing.T, is IntSet, s Set) {
+	t.Helper()
+
+	if is.Length() != s.Length() {
+		t.Fatalf("The IntSet has %d elements, instead of %d.", is.Length(), s.Length())
+	}
+
+	prev, n := int64(-1), 0
+	is.Each(func(x uint32) bool {
+		if int64(x) <= prev || !s.Has(x) {
+			t.Fatalf("The IntSet yields %d after %d.", x, prev)
+		}
+		prev = int64(x)
+		n++
+		return true
+	})
+
+	if n != s.Length() {
+		t.Fatalf("The IntSet yields %d elements, instead of %d.", n, s.Length())
+	}
+}
+
+func TestIntSetAgainstSet(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	is, s := intSetOf(randomElements(r))
+	sameElements(t, is, s)
+
+	for i := 0; i < 5000; i++ {
+		x := 1<<20 + uint32(r.Intn(1<<16))
+		if is.Remove(x) != s.Remove(x) {
+			t.Fatalf("Remove(%d) disagrees with Set.Remove.", x)
+		}
+	}
+	sameElements(t, is, s)
+
+	is.RunOptimize()
+	sameElements(t, is, s)
+
+	if !is.Add(2<<20+6000) || !s.Add(uint32(2<<20+6000)) {
+		t.Errorf("Adding to a run container failed.")
+	}
+	sameElements(t, is, s)
+}
+
+func TestIntSetAlgebra(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	is1, s1 := intSetOf(randomElements(r))
+	is2, s2 := intSetOf(randomElements(r))
+	is2.RunOptimize()
+
+	union, _ := s1.Union(s2)
+	got := is1.Union(is2)
+	sameElements(t, got, union)
+
+	// Set.Intersection compares every pair of elements, which is too slow
+	// for sets of this size.
+	intersection := NewSet()
+	for x := range s1.Set {
+		if s2.Has(x) {
+			intersection.Add(x)
+		}
+	}
+	got = is1.Intersection(is2)
+	sameElements(t, got, intersection)
+
+	difference, _ := s1.Difference(s2)
+	got = is1.Difference(is2)
+	sameElements(t, got, difference)
+
+	if !is1.Equal(is1.Union(is1)) || is1.Equal(is2) {
+		t.Errorf("Equal does not tell the sets apart.")
+	}
+}
+
+func TestIntSetRank(t *testing.T) {
+	is, _ := intSetOf([]uint32{1, 5, 70000, 70001, 70002})
+
+	for x, want := range map[uint32]int{0: 0, 1: 1, 4: 1, 5: 2, 70001: 4, 1 << 30: 5} {
+		if got := is.Rank(x); got != want {
+			t.Errorf("The rank of %d is %d, instead of %d.", x, got, want)
+		}
+	}
+
+	is.RunOptimize()
+	if got := is.Rank(70001); got != 4 {
+		t.Errorf("The rank of 70001 is %d after RunOptimize, instead of 4.", got)
+	}
+}
+
+func TestIntSetBinary(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	is, s := intSetOf(randomElements(r))
+	is.RunOptimize()
+
+	data, err := is.MarshalBinary()
+	if err != nil {
+		t.Fatalf("There was an error trying to encode the IntSet.\n%v", err)
+	}
+
+	var got IntSet
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatalf("There was an error trying to decode the IntSet.\n%v", err)
+	}
+	sameElements(t, got, s)
+
+	if err := got.UnmarshalBinary(data[:len(data)-1]); err == nil {
+		t.Errorf("Decoding a truncated IntSet succeeded.")
+	}
+}
+
+func TestIntSetConversion(t *testing.T) {
+	s := CreateSet(int8(1))
+	s.Add(int8(100))
+
+	is, err := IntSetFromSet(s)
+	if err != nil {
+		t.Fatalf("There was an error trying to convert %v.\n%v", s, err)
+	}
+
+	back, err := is.ToSet()
+	if err != nil || !back.Equal(s) || !back.SameType(s) {
+		t.Errorf("Converting %v back resulted in %v.", s, back)
+	}
+
+	is.Add(300)
+	if _, err := is.ToSet(); err == nil {
+		t.Errorf("Converting 300 to an int8 succeeded.")
+	}
+
+	if _, err := IntSetFromSet(CreateSet(-1)); err == nil {
+		t.Errorf("Converting -1 to a uint32 succeeded.")
+	}
+	if _, err := IntSetFromSet(CreateSet("a")); err == nil {
+		t.Errorf("Converting a set of strings succeeded.")
+	}
+
+	mixed := NewSet()
+	mixed.Add("a")
+	mixed.SetType(1)
+	mixed.Add(2)
+	if _, err := IntSetFromSet(mixed); err == nil {
+		t.Errorf("Converting %v, which holds a string, succeeded.", mixed)
+	} else if _, ok := err.(*TypeError); !ok {
+		t.Errorf("Converting %v returned %v.", mixed, err)
+	}
+}
@@ -0,0 +1,166 @@
+package set
+
+import (
+	"math"
+	"sort"
+)
+
+// LSH is a locality-sensitive hashing index of MinHash signatures. It finds the
+// keys whose sets are likely to have a Jaccard index of at least a threshold
+// with a given set, without comparing against every key. Signatures are split
+// into bands of rows, and two signatures are candidates if they agree on every
+// row of some band.
+type LSH struct {
+	threshold float64
+	bands     int
+	rows      int
+	size      int
+	seed      int64
+	seeded    bool
+	buckets   []map[uint64][]interface{}
+	sigs      map[interface{}]Signature
+}
+
+// NewLSH returns an empty LSH index for signatures of n hash values and the
+// given threshold. The number of bands and rows is chosen so that the
+// similarity at which two sets become likely candidates, (1/bands)^(1/rows),
+// is as close to the threshold as possible. It panics if n is not positive or
+// if the threshold is not in (0, 1].
+func NewLSH(n int, threshold float64) *LSH {
+	if n <= 0 {
+		panic("set: an LSH index needs signatures of at least one hash value")
+	}
+	if !(threshold > 0 && threshold <= 1) {
+		panic("set: the threshold of an LSH index must be in (0, 1]")
+	}
+
+	l := &LSH{threshold: threshold, size: n, sigs: make(map[interface{}]Signature)}
+
+	best := math.Inf(1)
+	for rows := 1; rows <= n; rows++ {
+		bands := n / rows
+		t := math.Pow(1/float64(bands), 1/float64(rows))
+		if d := math.Abs(t - threshold); d < best {
+			best, l.bands, l.rows = d, bands, rows
+		}
+	}
+
+	l.buckets = make([]map[uint64][]interface{}, l.bands)
+	for i := range l.buckets {
+		l.buckets[i] = make(map[uint64][]interface{})
+	}
+
+	return l
+}
+
+// Bands returns the number of bands, and of rows in every band, the index l
+// splits signatures into.
+func (l *LSH) Bands() (bands, rows int) {
+	return l.bands, l.rows
+}
+
+// Length returns the number of keys in the index l.
+func (l *LSH) Length() int {
+	return len(l.sigs)
+}
+
+// band returns the hash of the i-th band of the signature sig.
+func (l *LSH) band(sig Signature, i int) uint64 {
+	var h uint64
+	for _, v := range sig.mins[i*l.rows : (i+1)*l.rows] {
+		h = mix64(h ^ v)
+	}
+
+	return h
+}
+
+// check returns ErrSignatureMismatch if sig is not comparable with the
+// signatures in the index l.
+func (l *LSH) check(sig Signature) error {
+	if sig.Len() != l.size || (l.seeded && sig.seed != l.seed) {
+		return ErrSignatureMismatch
+	}
+
+	return nil
+}
+
+// Insert adds key to the index l, with the signature of its set. If key is
+// already in the index, its signature is replaced. If the signature does not
+// have the size of the index, or was not made with the same seed as the
+// signatures already inserted, ErrSignatureMismatch is returned.
+func (l *LSH) Insert(key interface{}, sig Signature) error {
+	if err := l.check(sig); err != nil {
+		return err
+	}
+
+	l.Remove(key)
+	l.seed, l.seeded = sig.seed, true
+	l.sigs[key] = sig
+
+	for i, bucket := range l.buckets {
+		h := l.band(sig, i)
+		bucket[h] = append(bucket[h], key)
+	}
+
+	return nil
+}
+
+// Remove removes key from the index l. It returns true if key was in the
+// index.
+func (l *LSH) Remove(key interface{}) bool {
+	sig, ok := l.sigs[key]
+	if !ok {
+		return false
+	}
+
+	delete(l.sigs, key)
+	for i, bucket := range l.buckets {
+		h := l.band(sig, i)
+		keys := bucket[h]
+		for j, k := range keys {
+			if k == key {
+				keys = append(keys[:j:j], keys[j+1:]...)
+				break
+			}
+		}
+
+		if len(keys) == 0 {
+			delete(bucket, h)
+		} else {
+			bucket[h] = keys
+		}
+	}
+
+	return true
+}
+
+// Query returns the keys whose signatures agree with sig on some band and whose
+// estimated similarity with sig is at least the threshold of the index l,
+// ordered by NaturalOrder. Keys of similar sets may still be missed, with a
+// probability that falls quickly as their similarity rises above the
+// threshold. If sig is not comparable with the signatures in the index,
+// ErrSignatureMismatch is returned.
+func (l *LSH) Query(sig Signature) ([]interface{}, error) {
+	if err := l.check(sig); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[interface{}]struct{})
+	var keys []interface{}
+	for i, bucket := range l.buckets {
+		for _, key := range bucket[l.band(sig, i)] {
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = exists
+
+			if sim, _ := sig.Similarity(l.sigs[key]); sim >= l.threshold {
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return NaturalOrder(keys[i], keys[j]) < 0 })
+
+	return keys, nil
+}
@@ -0,0 +1,74 @@
+package set
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestNewLSH(t *testing.T) {
+	for _, threshold := range []float64{0.3, 0.5, 0.8, 0.95} {
+		l := NewLSH(128, threshold)
+		bands, rows := l.Bands()
+		if bands*rows > 128 || bands < 1 || rows < 1 {
+			t.Errorf("The index for %v has %d bands of %d rows.", threshold, bands, rows)
+		}
+	}
+
+	low, _ := NewLSH(128, 0.3).Bands()
+	high, _ := NewLSH(128, 0.9).Bands()
+	if low <= high {
+		t.Errorf("A lower threshold has %d bands, and a higher %d.", low, high)
+	}
+}
+
+func TestLSHQuery(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	m := NewMinHasher(128, 9)
+	l := NewLSH(128, 0.7)
+
+	docs := make(map[string]Set)
+	for d := 0; d < 50; d++ {
+		s := NewSet()
+		for s.Length() < 100 {
+			s.Add(rnd.Intn(1000000))
+		}
+		docs[fmt.Sprintf("doc%02d", d)] = s
+	}
+
+	// A near-duplicate of doc07, with 5 of its 100 elements replaced.
+	orig := docs["doc07"]
+	dup := orig.Copy()
+	for _, elem := range sortedElements(orig, NaturalOrder)[:5] {
+		dup.Remove(elem)
+		dup.Add(-elem.(int) - 1)
+	}
+	docs["dup07"] = dup
+
+	for key, s := range docs {
+		if err := l.Insert(key, m.Signature(s)); err != nil {
+			t.Fatalf("Inserting %v returned %v.", key, err)
+		}
+	}
+
+	got, err := l.Query(m.Signature(docs["doc07"]))
+	if err != nil || fmt.Sprint(got) != "[doc07 dup07]" {
+		t.Errorf("The query for doc07 returned %v, %v.", got, err)
+	}
+
+	if !l.Remove("dup07") || l.Remove("dup07") || l.Length() != 50 {
+		t.Errorf("The key dup07 was not removed.")
+	}
+
+	if got, _ := l.Query(m.Signature(dup)); fmt.Sprint(got) != "[doc07]" {
+		t.Errorf("The query for dup07 returned %v.", got)
+	}
+
+	if err := l.Insert("other", NewMinHasher(128, 10).Signature(dup)); err != ErrSignatureMismatch {
+		t.Errorf("Inserting a signature with another seed returned %v.", err)
+	}
+
+	if _, err := l.Query(NewMinHasher(64, 9).Signature(dup)); err != ErrSignatureMismatch {
+		t.Errorf("Querying with a shorter signature returned %v.", err)
+	}
+}
@@ -0,0 +1,153 @@
+package set
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/rand"
+)
+
+// ErrSignatureMismatch is returned when two MinHash signatures, or a signature
+// and an LSH index, were not made by MinHashers with the same size and seed.
+var ErrSignatureMismatch = errors.New("set: the signatures are not comparable")
+
+// MinHasher computes MinHash signatures of sets: short, fixed-size summaries
+// from which the Jaccard index of two sets can be estimated, with an error of
+// about 1/sqrt(n) for n hash functions. The elements are hashed with their
+// canonical encoding, the one used by Fingerprint, so signatures of sets with
+// elements of any type are stable across processes.
+type MinHasher struct {
+	seed  int64
+	salts []uint64
+}
+
+// NewMinHasher returns a MinHasher with n hash functions, derived from seed.
+// Only signatures made with the same n and seed can be compared. It panics if
+// n is not positive.
+func NewMinHasher(n int, seed int64) *MinHasher {
+	if n <= 0 {
+		panic("set: a MinHasher needs at least one hash function")
+	}
+
+	rnd := rand.New(rand.NewSource(seed))
+	m := &MinHasher{seed: seed, salts: make([]uint64, n)}
+	for i := range m.salts {
+		m.salts[i] = rnd.Uint64()
+	}
+
+	return m
+}
+
+// Size returns the number of hash functions of the MinHasher m, which is the
+// length of its signatures.
+func (m *MinHasher) Size() int {
+	return len(m.salts)
+}
+
+// Signature returns the MinHash signature of the set s: for every hash
+// function, the smallest hash of an element of s.
+func (m *MinHasher) Signature(s Set) Signature {
+	sig := Signature{seed: m.seed, mins: make([]uint64, len(m.salts))}
+	for i := range sig.mins {
+		sig.mins[i] = math.MaxUint64
+	}
+
+	for elem := range s.Set {
+		h := hashElement(elem)
+		for i, salt := range m.salts {
+			if v := mix64(h.Lo^salt) + h.Hi; v < sig.mins[i] {
+				sig.mins[i] = v
+			}
+		}
+	}
+
+	return sig
+}
+
+// Signature is the MinHash signature of a set, made by a MinHasher.
+type Signature struct {
+	seed int64
+	mins []uint64
+}
+
+// Len returns the number of hash values in the signature s.
+func (s Signature) Len() int {
+	return len(s.mins)
+}
+
+// compatible checks that s1 and s2 were made by MinHashers with the same size
+// and seed.
+func (s1 Signature) compatible(s2 Signature) bool {
+	return s1.seed == s2.seed && len(s1.mins) == len(s2.mins)
+}
+
+// Similarity estimates the Jaccard index of the sets of the signatures s1 and
+// s2, as the fraction of their hash values that are equal. If the signatures
+// were not made by MinHashers with the same size and seed,
+// ErrSignatureMismatch is returned.
+func (s1 Signature) Similarity(s2 Signature) (float64, error) {
+	if !s1.compatible(s2) {
+		return 0, ErrSignatureMismatch
+	}
+
+	if len(s1.mins) == 0 {
+		return 1, nil
+	}
+
+	n := 0
+	for i, v := range s1.mins {
+		if v == s2.mins[i] {
+			n++
+		}
+	}
+
+	return float64(n) / float64(len(s1.mins)), nil
+}
+
+// The binary format of a Signature, all integers little-endian:
+//
+//	magic    "MHSG"
+//	seed     int64, of the MinHasher
+//	count    uint32, of hash values
+//	payload  count uint64s
+const signatureMagic = "MHSG"
+
+var errSignatureFormat = errors.New("set: invalid Signature encoding")
+
+// MarshalBinary encodes the signature s in a portable binary format.
+func (s Signature) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, 16+8*len(s.mins))
+	buf = append(buf, signatureMagic...)
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(s.seed))
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(s.mins)))
+
+	for _, v := range s.mins {
+		buf = binary.LittleEndian.AppendUint64(buf, v)
+	}
+
+	return buf, nil
+}
+
+// UnmarshalBinary decodes a signature that was encoded with MarshalBinary.
+func (s *Signature) UnmarshalBinary(data []byte) error {
+	if len(data) < 16 || string(data[:4]) != signatureMagic {
+		return errSignatureFormat
+	}
+
+	seed := int64(binary.LittleEndian.Uint64(data[4:]))
+	count := int(binary.LittleEndian.Uint32(data[12:]))
+	data = data[16:]
+
+	if len(data) != 8*count {
+		return errSignatureFormat
+	}
+
+	mins := make([]uint64, count)
+	for i := range mins {
+		mins[i] = binary.LittleEndian.Uint64(data[8*i:])
+	}
+
+	s.seed, s.mins = seed, mins
+
+	return nil
+}
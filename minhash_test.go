@@ -0,0 +1,86 @@
+package set
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMinHashSimilarity(t *testing.T) {
+	s1, s2 := NewSet(), NewSet()
+	for i := 0; i < 1000; i++ {
+		s1.Add(i)
+		s2.Add(i + 500)
+	}
+
+	m := NewMinHasher(256, 42)
+	sig1, sig2 := m.Signature(s1), m.Signature(s2)
+
+	exact, _ := s1.Jaccard(s2)
+	estimate, err := sig1.Similarity(sig2)
+	if err != nil || math.Abs(estimate-exact) > 0.1 {
+		t.Errorf("The estimated similarity of the sets is %v, %v, instead of about %v.", estimate, err, exact)
+	}
+
+	if same, _ := sig1.Similarity(m.Signature(s1.Copy())); same != 1 {
+		t.Errorf("The estimated similarity of a set with its copy is %v.", same)
+	}
+
+	empty := NewSet()
+	if sim, _ := m.Signature(empty).Similarity(m.Signature(NewSet())); sim != 1 {
+		t.Errorf("The estimated similarity of two empty sets is %v.", sim)
+	}
+	if sim, _ := m.Signature(empty).Similarity(sig1); sim != 0 {
+		t.Errorf("The estimated similarity of the empty set and %v elements is %v.", s1.Length(), sim)
+	}
+}
+
+func TestMinHashStable(t *testing.T) {
+	mixed := NewSet()
+	mixed.AddAll(1, "1", int64(1), 1.5, true, [2]int{1, 2})
+
+	sig := NewMinHasher(4, 7).Signature(mixed)
+	other := NewMinHasher(4, 7).Signature(mixed)
+	if sim, err := sig.Similarity(other); err != nil || sim != 1 {
+		t.Errorf("Two signatures of %v differ.", mixed)
+	}
+
+	// The hash of an element depends only on its canonical encoding, so
+	// this must never change.
+	if sig.mins[0] != 0x148ae29af30d6278 {
+		t.Errorf("The first hash value of the signature of %v is %#x.", mixed, sig.mins[0])
+	}
+
+	if _, err := sig.Similarity(NewMinHasher(4, 8).Signature(mixed)); err != ErrSignatureMismatch {
+		t.Errorf("Signatures with different seeds returned %v.", err)
+	}
+
+	if _, err := sig.Similarity(NewMinHasher(5, 7).Signature(mixed)); err != ErrSignatureMismatch {
+		t.Errorf("Signatures with different sizes returned %v.", err)
+	}
+}
+
+func TestSignatureBinary(t *testing.T) {
+	s := CreateSet("a")
+	s.AddAll("b", "c")
+	sig := NewMinHasher(16, 3).Signature(s)
+
+	data, err := sig.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Encoding the signature returned %v.", err)
+	}
+
+	var decoded Signature
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Decoding the signature returned %v.", err)
+	}
+
+	if sim, err := decoded.Similarity(sig); err != nil || sim != 1 {
+		t.Errorf("The decoded signature differs from the original: %v, %v.", sim, err)
+	}
+
+	for _, bad := range [][]byte{nil, data[:len(data)-1], append([]byte("XXXX"), data[4:]...)} {
+		if err := decoded.UnmarshalBinary(bad); err == nil {
+			t.Errorf("Decoding %v returned no error.", bad)
+		}
+	}
+}
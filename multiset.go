@@ -0,0 +1,241 @@
+package set
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Multiset is a set in which an element can exist more than once. It holds the
+// multiplicity of every element, that is, the number of times it exists.
+type Multiset struct {
+	counts       map[interface{}]int
+	length       int
+	elementsType reflect.Type
+}
+
+// Entry is an element of a Multiset together with its multiplicity.
+type Entry struct {
+	Elem  interface{}
+	Count int
+}
+
+// NewMultiset allocates memory for a new Multiset. A Multiset created this way
+// can have elements of varying types.
+func NewMultiset() (m Multiset) {
+	m.counts = make(map[interface{}]int)
+	m.elementsType = nil
+
+	return m
+}
+
+// SetType sets the type of the elements the multiset accepts, with the same
+// rules as Set.SetType.
+func (m *Multiset) SetType(elem interface{}) error {
+	newType := reflect.ValueOf(elem).Type()
+
+	if m.elementsType == nil {
+		m.elementsType = newType
+		return nil
+	}
+
+	return &TypeError{m.elementsType, newType, "Trying to re-set the set's type."}
+}
+
+// properType checks if elem is the same type as Multiset.elementsType.
+func (m *Multiset) properType(elem interface{}) bool {
+	return m.elementsType == nil || reflect.ValueOf(elem).Type() == m.elementsType
+}
+
+// SameType checks if the multiset m1 is of the same type as the multiset m2. If
+// it is, it returns true.
+func (m1 *Multiset) SameType(m2 Multiset) bool {
+	return m1.elementsType == m2.elementsType
+}
+
+// Add adds n copies of elem to the multiset m. If the element is not of the
+// correct type or n is not positive, no addition is performed and false is
+// returned. Otherwise, it returns true.
+func (m *Multiset) Add(elem interface{}, n int) bool {
+	if !m.properType(elem) || n <= 0 {
+		return false
+	}
+
+	m.counts[elem] += n
+	m.length += n
+
+	return true
+}
+
+// Remove removes up to n copies of elem from the multiset m and returns the
+// number of copies it actually removed.
+func (m *Multiset) Remove(elem interface{}, n int) int {
+	c := m.Count(elem)
+	if n <= 0 || c == 0 {
+		return 0
+	}
+
+	if n >= c {
+		n = c
+		delete(m.counts, elem)
+	} else {
+		m.counts[elem] = c - n
+	}
+	m.length -= n
+
+	return n
+}
+
+// Count returns the multiplicity of elem in the multiset m, which is 0 if the
+// element does not exist in it.
+func (m *Multiset) Count(elem interface{}) int {
+	if !m.properType(elem) {
+		return 0
+	}
+
+	return m.counts[elem]
+}
+
+// Has returns true if the element provided exists at least once in the
+// multiset, otherwise false.
+func (m *Multiset) Has(elem interface{}) bool {
+	return m.Count(elem) > 0
+}
+
+// Length returns the number of elements in the multiset m, counting every copy.
+// The number of distinct elements is Distinct().Length().
+func (m *Multiset) Length() int {
+	return m.length
+}
+
+// Empty returns true if the multiset is empty, otherwise false.
+func (m *Multiset) Empty() bool {
+	if m.Length() > 0 {
+		return false
+	}
+
+	return true
+}
+
+// Distinct returns a Set with the elements of the multiset m, and the same
+// type.
+func (m *Multiset) Distinct() Set {
+	s := NewSet()
+	s.elementsType = m.elementsType
+
+	for elem := range m.counts {
+		s.Add(elem)
+	}
+
+	return s
+}
+
+// Entries returns the elements of the multiset m with their multiplicities,
+// in no particular order.
+func (m *Multiset) Entries() []Entry {
+	entries := make([]Entry, 0, len(m.counts))
+	for elem, c := range m.counts {
+		entries = append(entries, Entry{elem, c})
+	}
+
+	return entries
+}
+
+// MostCommon returns the k elements of the multiset m with the highest
+// multiplicities, from the highest to the lowest. Elements with the same
+// multiplicity are ordered by NaturalOrder. If k is negative or greater than
+// the number of distinct elements, all of them are returned.
+func (m *Multiset) MostCommon(k int) []Entry {
+	entries := m.Entries()
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Count != entries[j].Count {
+			return entries[i].Count > entries[j].Count
+		}
+		return NaturalOrder(entries[i].Elem, entries[j].Elem) < 0
+	})
+
+	if k >= 0 && k < len(entries) {
+		entries = entries[:k]
+	}
+
+	return entries
+}
+
+// combine returns the multiset with the multiplicity fn(c1, c2) for every
+// element, where c1 and c2 are its multiplicities in m1 and m2.
+func (m1 *Multiset) combine(m2 Multiset, fn func(c1, c2 int) int) Multiset {
+	m := NewMultiset()
+	m.elementsType = m1.elementsType
+
+	for elem, c1 := range m1.counts {
+		m.Add(elem, fn(c1, m2.counts[elem]))
+	}
+
+	for elem, c2 := range m2.counts {
+		if _, ok := m1.counts[elem]; !ok {
+			m.Add(elem, fn(0, c2))
+		}
+	}
+
+	return m
+}
+
+// Sum returns the multiset in which the multiplicity of every element is the
+// sum of its multiplicities in m1 and m2.
+func (m1 *Multiset) Sum(m2 Multiset) (Multiset, error) {
+	if !m1.Empty() && !m2.Empty() {
+		if !m1.SameType(m2) {
+			return Multiset{}, &TypeError{m1.elementsType, m2.elementsType,
+				"The sets' types do not match."}
+		}
+	}
+
+	return m1.combine(m2, func(c1, c2 int) int { return c1 + c2 }), nil
+}
+
+// Union returns the multiset in which the multiplicity of every element is the
+// maximum of its multiplicities in m1 and m2.
+func (m1 *Multiset) Union(m2 Multiset) (Multiset, error) {
+	if !m1.Empty() && !m2.Empty() {
+		if !m1.SameType(m2) {
+			return Multiset{}, &TypeError{m1.elementsType, m2.elementsType,
+				"The sets' types do not match."}
+		}
+	}
+
+	return m1.combine(m2, func(c1, c2 int) int {
+		if c1 > c2 {
+			return c1
+		}
+		return c2
+	}), nil
+}
+
+// Intersection returns the multiset in which the multiplicity of every element
+// is the minimum of its multiplicities in m1 and m2.
+func (m1 *Multiset) Intersection(m2 Multiset) (Multiset, error) {
+	if !m1.Empty() && !m2.Empty() {
+		if !m1.SameType(m2) {
+			return Multiset{}, &TypeError{m1.elementsType, m2.elementsType,
+				"The sets' types do not match."}
+		}
+	}
+
+	return m1.combine(m2, func(c1, c2 int) int {
+		if c1 < c2 {
+			return c1
+		}
+		return c2
+	}), nil
+}
+
+// Difference returns the multiset in which the multiplicity of every element
+// is its multiplicity in m1 minus its multiplicity in m2, or 0 if that is
+// negative.
+func (m1 *Multiset) Difference(m2 Multiset) (Multiset, error) {
+	if !m1.SameType(m2) {
+		return Multiset{}, &TypeError{m1.elementsType, m2.elementsType,
+			"The sets' type do not match."}
+	}
+
+	return m1.combine(m2, func(c1, c2 int) int { return c1 - c2 }), nil
+}
@@ -0,0 +1,84 @@
+package set
+
+import (
+	"reflect"
+	"testing"
+)
+
+func multisetOf(counts map[interface{}]int) Multiset {
+	m := NewMultiset()
+	for elem, c := range counts {
+		m.Add(elem, c)
+	}
+
+	return m
+}
+
+func TestMultisetCounts(t *testing.T) {
+	m := NewMultiset()
+	m.SetType("")
+
+	m.Add("a", 2)
+	m.Add("a", 1)
+	m.Add("b", 1)
+
+	if m.Add(1, 1) || m.Add("c", 0) {
+		t.Errorf("An invalid element was added in the multiset %v", m)
+	}
+
+	if m.Count("a") != 3 || m.Count("c") != 0 || m.Length() != 4 {
+		t.Errorf("The multiset %v has the wrong counts.", m)
+	}
+
+	if n := m.Remove("a", 5); n != 3 || m.Has("a") {
+		t.Errorf("Removing 5 copies of \"a\" removed %d from the multiset %v.", n, m)
+	}
+
+	distinct := m.Distinct()
+	if !distinct.Equal(CreateSet("b")) || !distinct.SameType(CreateSet("b")) {
+		t.Errorf("The distinct elements of the multiset %v are %v.", m, distinct)
+	}
+}
+
+func TestMultisetAlgebra(t *testing.T) {
+	m1 := multisetOf(map[interface{}]int{"a": 3, "b": 1})
+	m2 := multisetOf(map[interface{}]int{"a": 1, "b": 2, "c": 1})
+
+	tests := []struct {
+		name string
+		op   func(Multiset) (Multiset, error)
+		want map[interface{}]int
+	}{
+		{"sum", m1.Sum, map[interface{}]int{"a": 4, "b": 3, "c": 1}},
+		{"union", m1.Union, map[interface{}]int{"a": 3, "b": 2, "c": 1}},
+		{"intersection", m1.Intersection, map[interface{}]int{"a": 1, "b": 1}},
+		{"difference", m1.Difference, map[interface{}]int{"a": 2}},
+	}
+
+	for _, test := range tests {
+		got, err := test.op(m2)
+		if err != nil || !reflect.DeepEqual(got.counts, test.want) {
+			t.Errorf("The %s of %v and %v resulted in %v, instead of %v.", test.name, m1, m2, got, test.want)
+		}
+	}
+
+	m3 := NewMultiset()
+	m3.SetType(1)
+	m3.Add(1, 1)
+	if _, err := m3.Union(m1); err == nil {
+		t.Errorf("The union of multisets of different types did not fail.")
+	}
+}
+
+func TestMultisetMostCommon(t *testing.T) {
+	m := multisetOf(map[interface{}]int{"a": 1, "b": 3, "c": 2, "d": 2})
+
+	want := []Entry{{"b", 3}, {"c", 2}, {"d", 2}}
+	if got := m.MostCommon(3); !reflect.DeepEqual(got, want) {
+		t.Errorf("The 3 most common elements are %v, instead of %v.", got, want)
+	}
+
+	if got := m.MostCommon(-1); len(got) != 4 {
+		t.Errorf("All the most common elements are %v.", got)
+	}
+}
@@ -0,0 +1,168 @@
+package set
+
+// Observer is notified synchronously of every successful mutation of the set it
+// is subscribed to. Elements that are rejected by Add, because they are of the
+// wrong type or already exist, and elements that Remove does not find, are
+// never reported.
+type Observer interface {
+	OnAdd(elem interface{})
+	OnRemove(elem interface{})
+	OnClear()
+}
+
+// BatchObserver is an Observer that is notified once for bulk operations, such
+// as AddAll, RemoveAll and Apply, instead of once for every element. Observers
+// that do not implement it receive the elements of a bulk operation one by
+// one, removals first, after the operation has finished.
+type BatchObserver interface {
+	Observer
+	OnBatch(added, removed []interface{})
+}
+
+// ObserverFuncs is an Observer that calls the function for each event. Nil
+// functions are skipped.
+type ObserverFuncs struct {
+	Add    func(elem interface{})
+	Remove func(elem interface{})
+	Clear  func()
+}
+
+// OnAdd calls f.Add, if it is not nil.
+func (f ObserverFuncs) OnAdd(elem interface{}) {
+	if f.Add != nil {
+		f.Add(elem)
+	}
+}
+
+// OnRemove calls f.Remove, if it is not nil.
+func (f ObserverFuncs) OnRemove(elem interface{}) {
+	if f.Remove != nil {
+		f.Remove(elem)
+	}
+}
+
+// OnClear calls f.Clear, if it is not nil.
+func (f ObserverFuncs) OnClear() {
+	if f.Clear != nil {
+		f.Clear()
+	}
+}
+
+// subscription wraps an Observer, so that it can be unsubscribed even if the
+// Observer itself is not comparable.
+type subscription struct {
+	Observer
+}
+
+// observers holds the subscriptions of a set and, while a bulk operation is in
+// progress, the elements it has added and removed so far.
+type observers struct {
+	subs           []*subscription
+	depth          int
+	pendingAdded   []interface{}
+	pendingRemoved []interface{}
+}
+
+// Subscribe registers o to be notified of the mutations of the set s, and
+// returns a function that cancels the subscription. Copies of s share the
+// same elements and thus the same observers.
+func (s *Set) Subscribe(o Observer) (unsubscribe func()) {
+	if s.observers == nil {
+		s.observers = &observers{}
+	}
+
+	obs := s.observers
+	sub := &subscription{o}
+	obs.subs = append(obs.subs, sub)
+
+	return func() {
+		for i, v := range obs.subs {
+			if v == sub {
+				obs.subs = append(obs.subs[:i:i], obs.subs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func (obs *observers) added(elem interface{}) {
+	if obs == nil || len(obs.subs) == 0 {
+		return
+	}
+
+	if obs.depth > 0 {
+		obs.pendingAdded = append(obs.pendingAdded, elem)
+		return
+	}
+
+	for _, sub := range obs.subs {
+		sub.OnAdd(elem)
+	}
+}
+
+func (obs *observers) removed(elem interface{}) {
+	if obs == nil || len(obs.subs) == 0 {
+		return
+	}
+
+	if obs.depth > 0 {
+		obs.pendingRemoved = append(obs.pendingRemoved, elem)
+		return
+	}
+
+	for _, sub := range obs.subs {
+		sub.OnRemove(elem)
+	}
+}
+
+func (obs *observers) cleared() {
+	if obs == nil {
+		return
+	}
+
+	// Whatever a bulk operation did before the set was cleared no longer
+	// matters.
+	obs.pendingAdded, obs.pendingRemoved = nil, nil
+
+	for _, sub := range obs.subs {
+		sub.OnClear()
+	}
+}
+
+// batch starts a bulk operation and returns the function that ends it and
+// delivers the notifications. Bulk operations may nest; only the outermost
+// one notifies.
+func (obs *observers) batch() (end func()) {
+	if obs == nil {
+		return func() {}
+	}
+
+	obs.depth++
+
+	return func() {
+		obs.depth--
+		if obs.depth > 0 {
+			return
+		}
+
+		added, removed := obs.pendingAdded, obs.pendingRemoved
+		obs.pendingAdded, obs.pendingRemoved = nil, nil
+		if len(added) == 0 && len(removed) == 0 {
+			return
+		}
+
+		for _, sub := range obs.subs {
+			if b, ok := sub.Observer.(BatchObserver); ok {
+				b.OnBatch(added, removed)
+				continue
+			}
+
+			for _, elem := range removed {
+				sub.OnRemove(elem)
+			}
+			for _, elem := range added {
+				sub.OnAdd(elem)
+			}
+		}
+	}
+}
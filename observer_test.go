@@ -0,0 +1,97 @@
+package set
+
+import (
+	"testing"
+)
+
+type recorder struct {
+	added, removed []interface{}
+	clears         int
+}
+
+func (r *recorder) OnAdd(elem interface{})    { r.added = append(r.added, elem) }
+func (r *recorder) OnRemove(elem interface{}) { r.removed = append(r.removed, elem) }
+func (r *recorder) OnClear()                  { r.clears++ }
+
+type batchRecorder struct {
+	recorder
+	batches int
+}
+
+func (r *batchRecorder) OnBatch(added, removed []interface{}) {
+	r.batches++
+	r.added = append(r.added, added...)
+	r.removed = append(r.removed, removed...)
+}
+
+func TestObserver(t *testing.T) {
+	s := CreateSet(1)
+	r := &recorder{}
+	unsubscribe := s.Subscribe(r)
+
+	s.Add(2)
+	s.Add(2)
+	s.Add("3")
+	s.Remove(1)
+	s.Remove(4)
+
+	if len(r.added) != 1 || r.added[0] != 2 {
+		t.Errorf("The observer saw the additions %v, instead of [2].", r.added)
+	}
+	if len(r.removed) != 1 || r.removed[0] != 1 {
+		t.Errorf("The observer saw the removals %v, instead of [1].", r.removed)
+	}
+
+	s.Clear()
+	if r.clears != 1 {
+		t.Errorf("The observer saw %d clears, instead of 1.", r.clears)
+	}
+
+	unsubscribe()
+	s.Add(5)
+	if len(r.added) != 1 {
+		t.Errorf("The observer saw the additions %v after unsubscribing.", r.added)
+	}
+}
+
+func TestObserverBatch(t *testing.T) {
+	s := CreateSet(1)
+	r := &recorder{}
+	b := &batchRecorder{}
+	s.Subscribe(r)
+	s.Subscribe(b)
+
+	s.AddAll(1, 2, 3, "4")
+
+	if b.batches != 1 || len(b.added) != 2 {
+		t.Errorf("The batch observer saw %d batches with the additions %v.", b.batches, b.added)
+	}
+	if len(r.added) != 2 {
+		t.Errorf("The observer saw the additions %v, instead of two.", r.added)
+	}
+
+	if err := s.Apply(Diff(s, CreateSet(4))); err != nil {
+		t.Errorf("There was an error trying to apply the delta to %v.\n%v", s, err)
+	}
+
+	if b.batches != 2 || len(b.added) != 3 || len(b.removed) != 3 {
+		t.Errorf("The batch observer saw %d batches with the additions %v and the removals %v.", b.batches, b.added, b.removed)
+	}
+	if len(r.added) != 3 || len(r.removed) != 3 {
+		t.Errorf("The observer saw the additions %v and the removals %v.", r.added, r.removed)
+	}
+}
+
+func TestObserverFuncs(t *testing.T) {
+	s := NewSet()
+	n := 0
+	s.Subscribe(ObserverFuncs{Add: func(interface{}) { n++ }})
+
+	s.Add(1)
+	s.Remove(1)
+	s.Clear()
+
+	if n != 1 {
+		t.Errorf("The observer saw %d additions, instead of 1.", n)
+	}
+}
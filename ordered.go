@@ -0,0 +1,276 @@
+package set
+
+import (
+	"reflect"
+)
+
+// OrderedSet is a set that remembers the order its elements were added in.
+// Iterating over it, and the results of its operations, follow that order.
+type OrderedSet struct {
+	elems        []interface{}
+	index        map[interface{}]int // The position of every element in elems
+	elementsType reflect.Type
+}
+
+// NewOrderedSet allocates memory for a new OrderedSet. An OrderedSet created
+// this way can have elements of varying types.
+func NewOrderedSet() (s OrderedSet) {
+	s.index = make(map[interface{}]int)
+	s.elementsType = nil
+
+	return s
+}
+
+// CreateOrderedSet creates an OrderedSet and inserts elem in it. As with
+// CreateSet, the type of the set is set to that of the element.
+func CreateOrderedSet(elem interface{}) (s OrderedSet) {
+	s.index = make(map[interface{}]int)
+	s.elementsType = reflect.ValueOf(elem).Type()
+
+	s.Add(elem)
+
+	return s
+}
+
+// SetType sets the type of the elements the set accepts, with the same rules as
+// Set.SetType.
+func (s *OrderedSet) SetType(elem interface{}) error {
+	newType := reflect.ValueOf(elem).Type()
+
+	if s.elementsType == nil {
+		s.elementsType = newType
+		return nil
+	}
+
+	return &TypeError{s.elementsType, newType, "Trying to re-set the set's type."}
+}
+
+// properType checks if elem is the same type as OrderedSet.elementsType.
+func (s *OrderedSet) properType(elem interface{}) bool {
+	return s.elementsType == nil || reflect.ValueOf(elem).Type() == s.elementsType
+}
+
+// SameType checks if the set s1 is of the same type as the set s2. If it is,
+// it returns true.
+func (s1 *OrderedSet) SameType(s2 OrderedSet) bool {
+	return s1.elementsType == s2.elementsType
+}
+
+// Add appends elem to the set s. If the element exists in the set or if the
+// element is not of the correct type, no addition is performed and false is
+// returned; in particular, the element keeps its position. Otherwise, it
+// returns true.
+func (s *OrderedSet) Add(elem interface{}) bool {
+	if !s.properType(elem) {
+		return false
+	}
+
+	if _, ok := s.index[elem]; ok {
+		return false
+	}
+
+	s.index[elem] = len(s.elems)
+	s.elems = append(s.elems, elem)
+
+	return true
+}
+
+// Has returns true if the element provided already exists in the set,
+// otherwise false.
+func (s *OrderedSet) Has(elem interface{}) bool {
+	if !s.properType(elem) {
+		return false
+	}
+
+	_, ok := s.index[elem]
+	return ok
+}
+
+// Remove removes elem from the set s, keeping the order of the rest of the
+// elements. It returns false if the element does not exist in the set. It
+// takes linear time.
+func (s *OrderedSet) Remove(elem interface{}) bool {
+	i := s.IndexOf(elem)
+	if i < 0 {
+		return false
+	}
+
+	s.elems = append(s.elems[:i], s.elems[i+1:]...)
+	delete(s.index, elem)
+	s.reindex(i)
+
+	return true
+}
+
+// reindex updates the positions of the elements from i onwards.
+func (s *OrderedSet) reindex(i int) {
+	for ; i < len(s.elems); i++ {
+		s.index[s.elems[i]] = i
+	}
+}
+
+// Length returns the number of elements in the set s.
+func (s *OrderedSet) Length() int {
+	return len(s.elems)
+}
+
+// Empty returns true if the set is empty, otherwise false.
+func (s *OrderedSet) Empty() bool {
+	if s.Length() > 0 {
+		return false
+	}
+
+	return true
+}
+
+// First returns the element that was added first and true, or false if the set
+// is empty.
+func (s *OrderedSet) First() (interface{}, bool) {
+	if s.Empty() {
+		return nil, false
+	}
+
+	return s.elems[0], true
+}
+
+// Last returns the element that was added last and true, or false if the set
+// is empty.
+func (s *OrderedSet) Last() (interface{}, bool) {
+	if s.Empty() {
+		return nil, false
+	}
+
+	return s.elems[len(s.elems)-1], true
+}
+
+// At returns the i-th element of the set s. It panics if i is out of range.
+func (s *OrderedSet) At(i int) interface{} {
+	return s.elems[i]
+}
+
+// IndexOf returns the position of elem in the set s, or -1 if it does not
+// exist in the set.
+func (s *OrderedSet) IndexOf(elem interface{}) int {
+	if !s.properType(elem) {
+		return -1
+	}
+
+	i, ok := s.index[elem]
+	if !ok {
+		return -1
+	}
+
+	return i
+}
+
+// MoveToEnd moves elem to the end of the set s, as if it was just added. It
+// returns false if the element does not exist in the set.
+func (s *OrderedSet) MoveToEnd(elem interface{}) bool {
+	i := s.IndexOf(elem)
+	if i < 0 {
+		return false
+	}
+
+	copy(s.elems[i:], s.elems[i+1:])
+	s.elems[len(s.elems)-1] = elem
+	s.reindex(i)
+
+	return true
+}
+
+// ElementsType returns the type of the elements the set accepts, or nil if it
+// accepts elements of any type.
+func (s *OrderedSet) ElementsType() reflect.Type {
+	return s.elementsType
+}
+
+// Each calls fn for every element of the set s, in order, until fn returns
+// false.
+func (s *OrderedSet) Each(fn func(elem interface{}) bool) {
+	for _, elem := range s.elems {
+		if !fn(elem) {
+			return
+		}
+	}
+}
+
+// Elements returns the elements of the set s, in order.
+func (s *OrderedSet) Elements() []interface{} {
+	return append([]interface{}(nil), s.elems...)
+}
+
+// Set returns a Set with the elements of the set s, and the same type.
+func (s *OrderedSet) Set() Set {
+	set := NewSet()
+	set.elementsType = s.elementsType
+
+	for _, elem := range s.elems {
+		set.Add(elem)
+	}
+
+	return set
+}
+
+// Union returns the union of the two sets. The elements of s1 come first, in
+// their order, followed by the rest of the elements of s2, in theirs.
+func (s1 *OrderedSet) Union(s2 OrderedSet) (OrderedSet, error) {
+	if !s1.Empty() && !s2.Empty() {
+		if !s1.SameType(s2) {
+			return OrderedSet{}, &TypeError{s1.elementsType, s2.elementsType,
+				"The sets' types do not match."}
+		}
+	}
+
+	s := NewOrderedSet()
+	s.elementsType = s1.elementsType
+
+	for _, v := range s1.elems {
+		s.Add(v)
+	}
+
+	for _, v := range s2.elems {
+		s.Add(v)
+	}
+
+	return s, nil
+}
+
+// Intersection returns the intersection of the two sets, in the order of s1.
+func (s1 *OrderedSet) Intersection(s2 OrderedSet) (OrderedSet, error) {
+	if !s1.Empty() && !s2.Empty() {
+		if !s1.SameType(s2) {
+			return OrderedSet{}, &TypeError{s1.elementsType, s2.elementsType,
+				"The sets' types do not match."}
+		}
+	}
+
+	s := NewOrderedSet()
+	s.elementsType = s1.elementsType
+
+	for _, v := range s1.elems {
+		if _, ok := s2.index[v]; ok {
+			s.Add(v)
+		}
+	}
+
+	return s, nil
+}
+
+// Difference returns the difference s1\s2, in the order of s1.
+func (s1 *OrderedSet) Difference(s2 OrderedSet) (OrderedSet, error) {
+	if !s1.SameType(s2) {
+		return OrderedSet{}, &TypeError{s1.elementsType, s2.elementsType,
+			"The sets' type do not match."}
+	}
+
+	s := NewOrderedSet()
+	s.elementsType = s1.elementsType
+
+	for _, v := range s1.elems {
+		if _, ok := s2.index[v]; !ok {
+			s.Add(v)
+		}
+	}
+
+	return s, nil
+}
@@ -0,0 +1,78 @@
+package set
+
+import (
+	"reflect"
+	"testing"
+)
+
+func orderedOf(elems ...interface{}) OrderedSet {
+	s := CreateOrderedSet(elems[0])
+	for _, elem := range elems[1:] {
+		s.Add(elem)
+	}
+
+	return s
+}
+
+func TestOrderedSetOrder(t *testing.T) {
+	s := orderedOf("b", "a", "c", "a")
+
+	if got, want := s.Elements(), []interface{}{"b", "a", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("The elements of the set are %v, instead of %v.", got, want)
+	}
+
+	if first, _ := s.First(); first != "b" {
+		t.Errorf("The first element of the set %v is %v, instead of \"b\".", s, first)
+	}
+	if last, _ := s.Last(); last != "c" {
+		t.Errorf("The last element of the set %v is %v, instead of \"c\".", s, last)
+	}
+
+	if !s.MoveToEnd("b") || s.IndexOf("b") != 2 || s.IndexOf("a") != 0 {
+		t.Errorf("\"b\" was not moved to the end of the set %v", s)
+	}
+
+	if !s.Remove("a") || s.IndexOf("c") != 0 || s.IndexOf("a") != -1 {
+		t.Errorf("\"a\" was not removed properly from the set %v", s)
+	}
+
+	if s.Add(1) {
+		t.Errorf("1 was added in the set %v", s)
+	}
+
+	empty := NewOrderedSet()
+	if _, ok := empty.First(); ok {
+		t.Errorf("The empty set has a first element.")
+	}
+}
+
+func TestOrderedSetAlgebra(t *testing.T) {
+	s1 := orderedOf(3, 1, 2)
+	s2 := orderedOf(4, 2, 5, 3)
+
+	union, err := s1.Union(s2)
+	if got, want := union.Elements(), []interface{}{3, 1, 2, 4, 5}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("The union of %v and %v resulted in %v, instead of %v.", s1, s2, got, want)
+	}
+
+	intersection, err := s1.Intersection(s2)
+	if got, want := intersection.Elements(), []interface{}{3, 2}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("The intersection of %v and %v resulted in %v, instead of %v.", s1, s2, got, want)
+	}
+
+	difference, err := s1.Difference(s2)
+	if got, want := difference.Elements(), []interface{}{1}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("The difference of %v from %v resulted in %v, instead of %v.", s2, s1, got, want)
+	}
+
+	if _, err := s1.Union(orderedOf("a")); err == nil {
+		t.Errorf("The union of %v with a set of strings succeeded.", s1)
+	}
+
+	set := s1.Set()
+	want := CreateSet(1)
+	want.AddAll(2, 3)
+	if !set.Equal(want) || !set.SameType(want) {
+		t.Errorf("The set %v is not equal to the set %v.", set, want)
+	}
+}
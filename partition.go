@@ -0,0 +1,352 @@
+package set
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// MaxPartitionsLength is the largest set whose partitions AllPartitions
+// enumerates. A set of n elements has Bell(n) partitions, and Bell(15) is
+// already more than a billion.
+const MaxPartitionsLength = 15
+
+// Partition is a partition of a set: a collection of non-empty, disjoint sets,
+// its blocks, whose union is the set. Blocks are kept in a deterministic order:
+// the elements of every block are ordered by NaturalOrder, and the blocks by
+// their first element. A Partition is immutable.
+type Partition struct {
+	blocks       [][]interface{}
+	block        map[interface{}]int
+	elementsType reflect.Type
+}
+
+// newPartition returns the partition with the given blocks, which must be
+// non-empty and disjoint, after putting them in order.
+func newPartition(elemsType reflect.Type, blocks [][]interface{}) Partition {
+	p := Partition{
+		blocks:       blocks,
+		block:        make(map[interface{}]int),
+		elementsType: elemsType,
+	}
+
+	for _, b := range p.blocks {
+		sort.Slice(b, func(i, j int) bool { return NaturalOrder(b[i], b[j]) < 0 })
+	}
+	sort.Slice(p.blocks, func(i, j int) bool {
+		return NaturalOrder(p.blocks[i][0], p.blocks[j][0]) < 0
+	})
+
+	for i, b := range p.blocks {
+		for _, elem := range b {
+			p.block[elem] = i
+		}
+	}
+
+	return p
+}
+
+// NewPartition partitions the set s into its equivalence classes under equiv,
+// which must be an equivalence relation: reflexive, symmetric and transitive.
+// The partition has the type of s. It calls equiv once for every element and
+// every class found so far.
+func NewPartition(s Set, equiv func(a, b interface{}) bool) Partition {
+	return newPartition(s.elementsType, classes(sortedElements(s, NaturalOrder), equiv))
+}
+
+// classes splits elems into the equivalence classes of equiv.
+func classes(elems []interface{}, equiv func(a, b interface{}) bool) [][]interface{} {
+	var blocks [][]interface{}
+
+next:
+	for _, elem := range elems {
+		for i, b := range blocks {
+			if equiv(b[0], elem) {
+				blocks[i] = append(b, elem)
+				continue next
+			}
+		}
+
+		blocks = append(blocks, []interface{}{elem})
+	}
+
+	return blocks
+}
+
+// ElementsType returns the type of the elements of the partitioned set, or nil
+// if it has no type.
+func (p *Partition) ElementsType() reflect.Type {
+	return p.elementsType
+}
+
+// Length returns the number of blocks of the partition p.
+func (p *Partition) Length() int {
+	return len(p.blocks)
+}
+
+// Has returns true if elem is an element of the partitioned set.
+func (p *Partition) Has(elem interface{}) bool {
+	_, ok := p.block[elem]
+
+	return ok
+}
+
+// blockSet returns the i-th block of p as a new Set.
+func (p *Partition) blockSet(i int) Set {
+	s := NewSet()
+	s.elementsType = p.elementsType
+
+	for _, elem := range p.blocks[i] {
+		s.Add(elem)
+	}
+
+	return s
+}
+
+// Blocks returns the blocks of the partition p as new sets, in order.
+func (p *Partition) Blocks() []Set {
+	blocks := make([]Set, len(p.blocks))
+	for i := range blocks {
+		blocks[i] = p.blockSet(i)
+	}
+
+	return blocks
+}
+
+// Block returns the block of the partition p that elem belongs to, and true.
+// If elem is not an element of the partitioned set, it returns false.
+func (p *Partition) Block(elem interface{}) (Set, bool) {
+	i, ok := p.block[elem]
+	if !ok {
+		return Set{}, false
+	}
+
+	return p.blockSet(i), true
+}
+
+// Set returns the partitioned set: the union of the blocks of p.
+func (p *Partition) Set() Set {
+	s := NewSet()
+	s.elementsType = p.elementsType
+
+	for elem := range p.block {
+		s.Add(elem)
+	}
+
+	return s
+}
+
+// String returns the blocks of the partition p in the form {{a, b}, {c}}.
+func (p *Partition) String() string {
+	blocks := make([]string, len(p.blocks))
+	for i, b := range p.blocks {
+		elems := make([]string, len(b))
+		for j, elem := range b {
+			elems[j] = fmt.Sprint(elem)
+		}
+		blocks[i] = "{" + strings.Join(elems, ", ") + "}"
+	}
+
+	return "{" + strings.Join(blocks, ", ") + "}"
+}
+
+// Refine splits every block of the partition p into the equivalence classes of
+// equiv within it. The result is the partition of the elements that are
+// equivalent both in p and under equiv.
+func (p *Partition) Refine(equiv func(a, b interface{}) bool) Partition {
+	var blocks [][]interface{}
+	for _, b := range p.blocks {
+		blocks = append(blocks, classes(b, equiv)...)
+	}
+
+	return newPartition(p.elementsType, blocks)
+}
+
+// compatible checks that p1 and p2 are partitions of the same set. If they are
+// not, it returns a TypeError, or a DomainError with an element of one that is
+// not an element of the other.
+func (p1 *Partition) compatible(p2 Partition) error {
+	if p1.elementsType != p2.elementsType {
+		return &TypeError{p1.elementsType, p2.elementsType,
+			"The partitions' types do not match."}
+	}
+
+	for elem := range p1.block {
+		if !p2.Has(elem) {
+			return &DomainError{Elem: elem}
+		}
+	}
+
+	for elem := range p2.block {
+		if !p1.Has(elem) {
+			return &DomainError{Elem: elem}
+		}
+	}
+
+	return nil
+}
+
+// Meet returns the coarsest partition that refines both p1 and p2: its blocks
+// are the non-empty intersections of a block of p1 with a block of p2. Both
+// must be partitions of the same set.
+func (p1 *Partition) Meet(p2 Partition) (Partition, error) {
+	if err := p1.compatible(p2); err != nil {
+		return Partition{}, err
+	}
+
+	return p1.Refine(func(a, b interface{}) bool {
+		return p2.block[a] == p2.block[b]
+	}), nil
+}
+
+// Join returns the finest partition that both p1 and p2 refine: two elements
+// are in the same block if they are connected by a chain of elements, each in
+// the same block as the next in p1 or in p2. Both must be partitions of the
+// same set.
+func (p1 *Partition) Join(p2 Partition) (Partition, error) {
+	if err := p1.compatible(p2); err != nil {
+		return Partition{}, err
+	}
+
+	d := NewDisjointSets()
+	d.elementsType = p1.elementsType
+
+	for _, p := range []*Partition{p1, &p2} {
+		for _, b := range p.blocks {
+			d.Add(b[0])
+			for _, elem := range b[1:] {
+				d.Union(b[0], elem)
+			}
+		}
+	}
+
+	return d.Partition(), nil
+}
+
+// Refines returns true if the partition p1 refines p2: p1 and p2 are partitions
+// of the same set and every block of p1 is a subset of a block of p2.
+func (p1 *Partition) Refines(p2 Partition) bool {
+	if p1.compatible(p2) != nil {
+		return false
+	}
+
+	for _, b := range p1.blocks {
+		for _, elem := range b[1:] {
+			if p2.block[elem] != p2.block[b[0]] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Equal returns true if p1 and p2 are partitions of the same set with the same
+// blocks.
+func (p1 *Partition) Equal(p2 Partition) bool {
+	return len(p1.blocks) == len(p2.blocks) && p1.Refines(p2)
+}
+
+// PartitionIterator yields the partitions of a set, one at a time.
+type PartitionIterator struct {
+	elems        []interface{}
+	elementsType reflect.Type
+	// growth is a restricted growth string: growth[i] is the block of the
+	// i-th element, and it is at most one more than the largest of the
+	// blocks before it, max[i-1].
+	growth  []int
+	max     []int
+	started bool
+	done    bool
+}
+
+// AllPartitions returns an iterator over every partition of the set s, with the
+// elements ordered by NaturalOrder: first the partition with a single block,
+// last the one with a block for every element. If s has more than
+// MaxPartitionsLength elements, ErrTooLarge is returned.
+func AllPartitions(s Set) (*PartitionIterator, error) {
+	if s.Length() > MaxPartitionsLength {
+		return nil, ErrTooLarge
+	}
+
+	it := &PartitionIterator{
+		elems:        sortedElements(s, NaturalOrder),
+		elementsType: s.elementsType,
+		growth:       make([]int, s.Length()),
+		max:          make([]int, s.Length()),
+	}
+
+	return it, nil
+}
+
+// Next advances the iterator to the next partition, which is then returned by
+// Partition. It returns false when there are no more partitions.
+func (it *PartitionIterator) Next() bool {
+	if it.done {
+		return false
+	}
+
+	if !it.started {
+		it.started = true
+		return true
+	}
+
+	i := len(it.growth) - 1
+	for i > 0 && it.growth[i] > it.max[i-1] {
+		i--
+	}
+
+	if i <= 0 {
+		it.done = true
+		return false
+	}
+
+	it.growth[i]++
+	it.max[i] = it.max[i-1]
+	if it.growth[i] > it.max[i] {
+		it.max[i] = it.growth[i]
+	}
+
+	for j := i + 1; j < len(it.growth); j++ {
+		it.growth[j] = 0
+		it.max[j] = it.max[i]
+	}
+
+	return true
+}
+
+// Partition returns the current partition of the iterator.
+func (it *PartitionIterator) Partition() Partition {
+	var blocks [][]interface{}
+	for i, b := range it.growth {
+		if b == len(blocks) {
+			blocks = append(blocks, nil)
+		}
+		blocks[b] = append(blocks[b], it.elems[i])
+	}
+
+	return newPartition(it.elementsType, blocks)
+}
+
+// Bell returns the number of partitions of a set of n elements, the n-th Bell
+// number. It panics if n is negative.
+func Bell(n int) *big.Int {
+	if n < 0 {
+		panic("set: Bell is not defined for negative numbers")
+	}
+
+	// Build the Bell triangle row by row. Every row starts with the last
+	// number of the previous one, and the first number of row n is Bell(n).
+	row := []*big.Int{big.NewInt(1)}
+	for i := 0; i < n; i++ {
+		next := []*big.Int{new(big.Int).Set(row[len(row)-1])}
+		for _, x := range row {
+			next = append(next, new(big.Int).Add(next[len(next)-1], x))
+		}
+		row = next
+	}
+
+	return row[0]
+}
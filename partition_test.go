@@ -0,0 +1,165 @@
+package set
+
+import (
+	"fmt"
+	"testing"
+)
+
+func sameParity(a, b interface{}) bool {
+	return a.(int)%2 == b.(int)%2
+}
+
+func sameThird(a, b interface{}) bool {
+	return a.(int)%3 == b.(int)%3
+}
+
+func TestNewPartition(t *testing.T) {
+	s := CreateSet(1)
+	s.AddAll(2, 3, 4, 5, 6)
+
+	p := NewPartition(s, sameParity)
+	if p.String() != "{{1, 3, 5}, {2, 4, 6}}" || p.Length() != 2 {
+		t.Errorf("The partition of %v by parity is %v.", s, p.String())
+	}
+
+	if p.ElementsType() != s.ElementsType() {
+		t.Errorf("The partition %v does not have the type of %v.", p.String(), s)
+	}
+
+	b, ok := p.Block(4)
+	if !ok || b.Length() != 3 || !b.Has(2) || !b.SameType(s) {
+		t.Errorf("The block of 4 in %v is %v.", p.String(), b)
+	}
+
+	if _, ok := p.Block(7); ok {
+		t.Errorf("The element 7 has a block in %v.", p.String())
+	}
+
+	union := p.Set()
+	if !union.Equal(s) {
+		t.Errorf("The union of the blocks of %v is %v.", p.String(), union)
+	}
+}
+
+func TestRefineMeetJoin(t *testing.T) {
+	s := CreateSet(0)
+	for i := 1; i < 12; i++ {
+		s.Add(i)
+	}
+
+	parity := NewPartition(s, sameParity)
+	third := NewPartition(s, sameThird)
+
+	meet, err := parity.Meet(third)
+	refined := parity.Refine(sameThird)
+	if err != nil || meet.Length() != 6 || !meet.Equal(refined) {
+		t.Errorf("The meet of %v and %v is %v.", parity.String(), third.String(), meet.String())
+	}
+
+	if !meet.Refines(parity) || !meet.Refines(third) || parity.Refines(meet) {
+		t.Errorf("The meet %v does not refine its operands.", meet.String())
+	}
+
+	join, err := parity.Join(third)
+	if err != nil || join.Length() != 1 {
+		t.Errorf("The join of %v and %v is %v.", parity.String(), third.String(), join.String())
+	}
+
+	sameHalf := func(a, b interface{}) bool { return a.(int)/6 == b.(int)/6 }
+	halves := NewPartition(s, sameHalf)
+	pairs := halves.Refine(func(a, b interface{}) bool { return a.(int)/2 == b.(int)/2 })
+	shifted := halves.Refine(func(a, b interface{}) bool { return (a.(int)+1)/2 == (b.(int)+1)/2 })
+	join, err = pairs.Join(shifted)
+	if err != nil || !join.Equal(halves) {
+		t.Errorf("The join of %v and %v is %v.", pairs.String(), shifted.String(), join.String())
+	}
+}
+
+func TestPartitionMismatch(t *testing.T) {
+	s1 := CreateSet(1)
+	s1.AddAll(2, 3)
+	s2 := CreateSet(1)
+	s2.AddAll(2, 4)
+
+	p1, p2 := NewPartition(s1, sameParity), NewPartition(s2, sameParity)
+	if _, err := p1.Meet(p2); err == nil {
+		t.Errorf("The meet of %v and %v returned no error.", p1.String(), p2.String())
+	} else if _, ok := err.(*DomainError); !ok {
+		t.Errorf("The meet of %v and %v returned %v.", p1.String(), p2.String(), err)
+	}
+
+	words := NewPartition(CreateSet("a"), func(a, b interface{}) bool { return true })
+	if _, err := p1.Join(words); err == nil {
+		t.Errorf("The join of %v and %v returned no error.", p1.String(), words.String())
+	} else if _, ok := err.(*TypeError); !ok {
+		t.Errorf("The join of %v and %v returned %v.", p1.String(), words.String(), err)
+	}
+
+	if p1.Refines(p2) || p1.Equal(p2) {
+		t.Errorf("The partitions %v and %v are comparable.", p1.String(), p2.String())
+	}
+}
+
+func TestAllPartitions(t *testing.T) {
+	for n := 0; n <= 6; n++ {
+		s := NewSet()
+		for i := 0; i < n; i++ {
+			s.Add(i)
+		}
+
+		it, err := AllPartitions(s)
+		if err != nil {
+			t.Fatalf("The partitions of %v returned %v.", s, err)
+		}
+
+		seen := make(map[string]bool)
+		for it.Next() {
+			p := it.Partition()
+			if union := p.Set(); !union.Equal(s) {
+				t.Errorf("The partition %v is not a partition of %v.", p.String(), s)
+			}
+			seen[p.String()] = true
+		}
+
+		if want := Bell(n).Int64(); int64(len(seen)) != want {
+			t.Errorf("The set %v has %d partitions, instead of %d.", s, len(seen), want)
+		}
+	}
+
+	s := CreateSet(1)
+	s.AddAll(2, 3)
+	it, _ := AllPartitions(s)
+
+	var got []string
+	for it.Next() {
+		p := it.Partition()
+		got = append(got, p.String())
+	}
+
+	want := "[{{1, 2, 3}} {{1, 2}, {3}} {{1, 3}, {2}} {{1}, {2, 3}} {{1}, {2}, {3}}]"
+	if fmt.Sprint(got) != want {
+		t.Errorf("The partitions of %v are %v, instead of %v.", s, got, want)
+	}
+
+	large := NewSet()
+	for i := 0; i <= MaxPartitionsLength; i++ {
+		large.Add(i)
+	}
+
+	if _, err := AllPartitions(large); err != ErrTooLarge {
+		t.Errorf("The partitions of a set of %d elements returned %v.", large.Length(), err)
+	}
+}
+
+func TestBell(t *testing.T) {
+	want := []int64{1, 1, 2, 5, 15, 52, 203, 877, 4140}
+	for n, b := range want {
+		if got := Bell(n); got.Int64() != b {
+			t.Errorf("Bell(%d) is %v, instead of %d.", n, got, b)
+		}
+	}
+
+	if got := Bell(30).String(); got != "846749014511809332450147" {
+		t.Errorf("Bell(30) is %v.", got)
+	}
+}
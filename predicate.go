@@ -0,0 +1,234 @@
+package set
+
+import (
+	"errors"
+	"reflect"
+)
+
+// ErrNotFinite is returned by operations that need to enumerate a set, when the
+// set is not known to be finite.
+var ErrNotFinite = errors.New("set: the set is not finite")
+
+// PredicateSet is a set defined by a rule rather than by its elements: an
+// element belongs to it if a predicate holds for it. Such a set may be
+// infinite, e.g. "all even ints". Combining PredicateSets is lazy; nothing is
+// evaluated until membership is asked for.
+//
+// A PredicateSet is finite when it is built from a Set with PredicateOf, or
+// combined from finite sets in a way that keeps it finite: a union of finite
+// sets, an intersection with a finite set, or a difference from a finite set.
+// Only finite sets can be enumerated.
+type PredicateSet struct {
+	pred         func(elem interface{}) bool
+	each         func(fn func(elem interface{}) bool) // nil for sets not known to be finite
+	elementsType reflect.Type
+}
+
+// NewPredicateSet creates a PredicateSet of the elements for which pred
+// returns true. A PredicateSet created this way can have elements of varying
+// types, and pred must accept any of them.
+func NewPredicateSet(pred func(elem interface{}) bool) (p PredicateSet) {
+	p.pred = pred
+	p.elementsType = nil
+
+	return p
+}
+
+// PredicateOf creates a finite PredicateSet with the elements of the set s,
+// and the same type. It refers to s, so later changes of s are reflected.
+func PredicateOf(s Set) (p PredicateSet) {
+	p.pred = s.Has
+	p.each = func(fn func(elem interface{}) bool) {
+		for elem := range s.Set {
+			if !fn(elem) {
+				return
+			}
+		}
+	}
+	p.elementsType = s.elementsType
+
+	return p
+}
+
+// SetType sets the type of the elements the set accepts, with the same rules as
+// Set.SetType. Elements of other types do not belong to the set, whatever the
+// predicate says about them.
+func (p *PredicateSet) SetType(elem interface{}) error {
+	newType := reflect.ValueOf(elem).Type()
+
+	if p.elementsType == nil {
+		p.elementsType = newType
+		return nil
+	}
+
+	return &TypeError{p.elementsType, newType, "Trying to re-set the set's type."}
+}
+
+// SameType checks if the set p1 is of the same type as the set p2. If it is,
+// it returns true.
+func (p1 *PredicateSet) SameType(p2 PredicateSet) bool {
+	return p1.elementsType == p2.elementsType
+}
+
+// compatible checks if the types of the two sets allow to combine them: both
+// sets must have the same type, unless either of them has none.
+func (p1 *PredicateSet) compatible(p2 PredicateSet) error {
+	if p1.elementsType != nil && p2.elementsType != nil && !p1.SameType(p2) {
+		return &TypeError{p1.elementsType, p2.elementsType, "The sets' types do not match."}
+	}
+
+	return nil
+}
+
+// Has returns true if the element provided is of the correct type and the
+// predicate of the set holds for it, otherwise false.
+func (p *PredicateSet) Has(elem interface{}) bool {
+	if p.elementsType != nil && reflect.ValueOf(elem).Type() != p.elementsType {
+		return false
+	}
+
+	return p.pred(elem)
+}
+
+// Finite returns true if the set p is known to be finite, which means it can
+// be enumerated, otherwise false.
+func (p *PredicateSet) Finite() bool {
+	return p.each != nil
+}
+
+// Each calls fn for every element of the set p until fn returns false. If p is
+// not known to be finite, ErrNotFinite is returned.
+func (p *PredicateSet) Each(fn func(elem interface{}) bool) error {
+	if !p.Finite() {
+		return ErrNotFinite
+	}
+
+	p.each(fn)
+
+	return nil
+}
+
+// Length returns the number of elements in the set p. If p is not known to be
+// finite, ErrNotFinite is returned.
+func (p *PredicateSet) Length() (int, error) {
+	n := 0
+	err := p.Each(func(interface{}) bool {
+		n++
+		return true
+	})
+
+	return n, err
+}
+
+// Materialize returns a Set with the elements of the set p, and the same type.
+// If p is not known to be finite, ErrNotFinite is returned.
+func (p *PredicateSet) Materialize() (Set, error) {
+	s := NewSet()
+	s.elementsType = p.elementsType
+
+	if err := p.Each(func(elem interface{}) bool {
+		s.Add(elem)
+		return true
+	}); err != nil {
+		return Set{}, err
+	}
+
+	return s, nil
+}
+
+// Union returns the set of the elements that belong to p1 or p2. It is finite
+// if both sets are. It has a type only if both sets have the same type.
+func (p1 *PredicateSet) Union(p2 PredicateSet) (PredicateSet, error) {
+	if err := p1.compatible(p2); err != nil {
+		return PredicateSet{}, err
+	}
+
+	a, b := *p1, p2
+	p := NewPredicateSet(func(elem interface{}) bool { return a.Has(elem) || b.Has(elem) })
+	if a.SameType(b) {
+		p.elementsType = a.elementsType
+	}
+
+	if a.Finite() && b.Finite() {
+		p.each = func(fn func(elem interface{}) bool) {
+			stopped := false
+			a.each(func(elem interface{}) bool {
+				stopped = !fn(elem)
+				return !stopped
+			})
+			if stopped {
+				return
+			}
+
+			b.each(func(elem interface{}) bool {
+				return a.Has(elem) || fn(elem)
+			})
+		}
+	}
+
+	return p, nil
+}
+
+// Intersection returns the set of the elements that belong to both p1 and p2.
+// It is finite if either set is. It has the type of either set that has one.
+func (p1 *PredicateSet) Intersection(p2 PredicateSet) (PredicateSet, error) {
+	if err := p1.compatible(p2); err != nil {
+		return PredicateSet{}, err
+	}
+
+	a, b := *p1, p2
+	p := NewPredicateSet(func(elem interface{}) bool { return a.Has(elem) && b.Has(elem) })
+	p.elementsType = a.elementsType
+	if p.elementsType == nil {
+		p.elementsType = b.elementsType
+	}
+
+	// Enumerate the finite set, keeping the elements of the other.
+	finite, other := a, b
+	if !finite.Finite() {
+		finite, other = b, a
+	}
+
+	if finite.Finite() {
+		p.each = func(fn func(elem interface{}) bool) {
+			finite.each(func(elem interface{}) bool {
+				return !other.Has(elem) || fn(elem)
+			})
+		}
+	}
+
+	return p, nil
+}
+
+// Difference returns the set of the elements that belong to p1 but not to p2.
+// It is finite if p1 is. It has the type of p1.
+func (p1 *PredicateSet) Difference(p2 PredicateSet) (PredicateSet, error) {
+	if err := p1.compatible(p2); err != nil {
+		return PredicateSet{}, err
+	}
+
+	a, b := *p1, p2
+	p := NewPredicateSet(func(elem interface{}) bool { return a.Has(elem) && !b.Has(elem) })
+	p.elementsType = a.elementsType
+
+	if a.Finite() {
+		p.each = func(fn func(elem interface{}) bool) {
+			a.each(func(elem interface{}) bool {
+				return b.Has(elem) || fn(elem)
+			})
+		}
+	}
+
+	return p, nil
+}
+
+// Complement returns the set of the elements that do not belong to p. If p has
+// a type, the complement is taken among the values of that type; otherwise,
+// among all values. It is never finite.
+func (p *PredicateSet) Complement() PredicateSet {
+	a := *p
+	c := NewPredicateSet(func(elem interface{}) bool { return !a.pred(elem) })
+	c.elementsType = a.elementsType
+
+	return c
+}
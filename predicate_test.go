@@ -0,0 +1,80 @@
+package set
+
+import (
+	"strings"
+	"testing"
+)
+
+func even() PredicateSet {
+	p := NewPredicateSet(func(elem interface{}) bool { return elem.(int)%2 == 0 })
+	p.SetType(0)
+
+	return p
+}
+
+func TestPredicateSet(t *testing.T) {
+	p := even()
+
+	if !p.Has(2) || p.Has(3) || p.Has("2") {
+		t.Errorf("The set of even ints does not have the right elements.")
+	}
+
+	if _, err := p.Length(); err != ErrNotFinite {
+		t.Errorf("The length of the set of even ints did not fail.")
+	}
+
+	c := p.Complement()
+	if !c.Has(3) || c.Has(2) || c.Has("3") {
+		t.Errorf("The complement of the set of even ints does not have the right elements.")
+	}
+
+	admins := NewPredicateSet(func(elem interface{}) bool { return strings.HasPrefix(elem.(string), "adm-") })
+	admins.SetType("")
+	if !admins.Has("adm-root") || admins.Has("usr-root") {
+		t.Errorf("The set of admins does not have the right elements.")
+	}
+}
+
+func TestPredicateSetAlgebra(t *testing.T) {
+	s := CreateSet(1)
+	s.AddAll(2, 3, 4)
+	concrete := PredicateOf(s)
+	p := even()
+
+	intersection, err := p.Intersection(concrete)
+	if err != nil {
+		t.Fatalf("There was an error trying to make the intersection.\n%v", err)
+	}
+
+	got, err := intersection.Materialize()
+	want := CreateSet(2)
+	want.Add(4)
+	if err != nil || !got.Equal(want) {
+		t.Errorf("The even elements of %v are %v, instead of %v.", s, got, want)
+	}
+
+	difference, _ := concrete.Difference(p)
+	if n, err := difference.Length(); err != nil || n != 2 {
+		t.Errorf("The odd elements of %v are %d.", s, n)
+	}
+
+	union, _ := p.Union(concrete)
+	if !union.Has(3) || !union.Has(6) || union.Has(5) || union.Finite() {
+		t.Errorf("The union of the even ints and %v is wrong.", s)
+	}
+
+	// Changes of the concrete set are reflected.
+	s.Add(6)
+	if n, _ := intersection.Length(); n != 3 {
+		t.Errorf("The intersection did not see the change of %v.", s)
+	}
+
+	finite, _ := concrete.Union(PredicateOf(CreateSet(5)))
+	if n, err := finite.Length(); err != nil || n != 6 {
+		t.Errorf("The union of finite sets has %d elements, instead of 6.\n%v", n, err)
+	}
+
+	if _, err := p.Union(PredicateOf(CreateSet("a"))); err == nil {
+		t.Errorf("The union of sets of different types succeeded.")
+	}
+}
@@ -0,0 +1,229 @@
+package set
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Tuple is an ordered, fixed-length sequence of values. Tuples are hashable,
+// and two tuples are equal if they have equal values with the same types, so
+// they can be elements of a Set.
+//
+// Every position of a tuple has a type: the type of the elements of the set
+// it was taken from, or no type if that set accepts elements of any type.
+type Tuple struct {
+	// v holds a struct, built with reflect.StructOf, with a field for each
+	// position. Comparing tuples compares these structs.
+	v interface{}
+}
+
+var interfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// newTuple returns the tuple of values, whose positions have the given types.
+// A nil type means no type.
+func newTuple(types []reflect.Type, values []interface{}) Tuple {
+	fields := make([]reflect.StructField, len(types))
+	for i, t := range types {
+		if t == nil {
+			t = interfaceType
+		}
+		fields[i] = reflect.StructField{Name: "F" + strconv.Itoa(i), Type: t}
+	}
+
+	v := reflect.New(reflect.StructOf(fields)).Elem()
+	for i, value := range values {
+		if value != nil {
+			v.Field(i).Set(reflect.ValueOf(value))
+		}
+	}
+
+	return Tuple{v.Interface()}
+}
+
+// NewTuple returns the tuple of values. Every position has the type of its
+// value, so the tuple is equal to the tuples Product makes out of sets with
+// those types.
+func NewTuple(values ...interface{}) Tuple {
+	types := make([]reflect.Type, len(values))
+	for i, value := range values {
+		types[i] = reflect.TypeOf(value)
+	}
+
+	return newTuple(types, values)
+}
+
+// Len returns the number of positions of the tuple t.
+func (t Tuple) Len() int {
+	if t.v == nil {
+		return 0
+	}
+
+	return reflect.ValueOf(t.v).NumField()
+}
+
+// At returns the value at position i of the tuple t. It panics if i is out of
+// range.
+func (t Tuple) At(i int) interface{} {
+	return reflect.ValueOf(t.v).Field(i).Interface()
+}
+
+// Values returns the values of the tuple t, in order.
+func (t Tuple) Values() []interface{} {
+	values := make([]interface{}, t.Len())
+	for i := range values {
+		values[i] = t.At(i)
+	}
+
+	return values
+}
+
+// Types returns the types of the positions of the tuple t, in order. Positions
+// with no type are nil.
+func (t Tuple) Types() []reflect.Type {
+	types := make([]reflect.Type, t.Len())
+	for i := range types {
+		if ft := reflect.TypeOf(t.v).Field(i).Type; ft != interfaceType {
+			types[i] = ft
+		}
+	}
+
+	return types
+}
+
+// String returns the values of the tuple t in the form (a, b, c).
+func (t Tuple) String() string {
+	values := make([]string, t.Len())
+	for i := range values {
+		values[i] = fmt.Sprint(t.At(i))
+	}
+
+	return "(" + strings.Join(values, ", ") + ")"
+}
+
+// ProductIterator yields the tuples of the Cartesian product of some sets, one
+// at a time, so that the product is never held in memory:
+//
+//	it := set.Product(oses, arches, versions)
+//	for it.Next() {
+//		t := it.Tuple()
+//		...
+//	}
+type ProductIterator struct {
+	elems   [][]interface{}
+	types   []reflect.Type
+	idx     []int
+	started bool
+	done    bool
+	current Tuple
+}
+
+// sortedElements returns the elements of the set s ordered by cmp.
+func sortedElements(s Set, cmp Comparator) []interface{} {
+	elems := make([]interface{}, 0, len(s.Set))
+	for elem := range s.Set {
+		elems = append(elems, elem)
+	}
+
+	sort.Slice(elems, func(i, j int) bool { return cmp(elems[i], elems[j]) < 0 })
+
+	return elems
+}
+
+// typedElements returns the elements of the set s that are of its type,
+// ordered by cmp. A set may hold elements of other types, if they were added
+// before its type was set; they are left out.
+func typedElements(s Set, cmp Comparator) []interface{} {
+	elems := sortedElements(s, cmp)
+	if s.elementsType == nil {
+		return elems
+	}
+
+	typed := elems[:0]
+	for _, elem := range elems {
+		if reflect.TypeOf(elem) == s.elementsType {
+			typed = append(typed, elem)
+		}
+	}
+
+	return typed
+}
+
+// Product returns an iterator over the Cartesian product of the sets: every
+// tuple whose i-th value is an element of the i-th set, with the type of that
+// set. Elements of a set that are not of its type are left out. The elements
+// of every set are ordered by NaturalOrder, and the tuples are yielded in
+// lexicographic order, the last position changing fastest. Only the elements
+// of the sets are copied, not the product.
+func Product(sets ...Set) *ProductIterator {
+	it := &ProductIterator{
+		elems: make([][]interface{}, len(sets)),
+		types: make([]reflect.Type, len(sets)),
+		idx:   make([]int, len(sets)),
+	}
+
+	for i, s := range sets {
+		it.elems[i] = typedElements(s, NaturalOrder)
+		it.types[i] = s.elementsType
+
+		if len(it.elems[i]) == 0 {
+			it.done = true
+		}
+	}
+
+	return it
+}
+
+// Next advances the iterator to the next tuple, which is then returned by
+// Tuple. It returns false when there are no more tuples.
+func (it *ProductIterator) Next() bool {
+	if it.done {
+		return false
+	}
+
+	if it.started {
+		i := len(it.idx) - 1
+		for ; i >= 0; i-- {
+			it.idx[i]++
+			if it.idx[i] < len(it.elems[i]) {
+				break
+			}
+			it.idx[i] = 0
+		}
+
+		if i < 0 {
+			it.done = true
+			return false
+		}
+	}
+	it.started = true
+
+	values := make([]interface{}, len(it.idx))
+	for i, j := range it.idx {
+		values[i] = it.elems[i][j]
+	}
+	it.current = newTuple(it.types, values)
+
+	return true
+}
+
+// Tuple returns the current tuple of the iterator.
+func (it *ProductIterator) Tuple() Tuple {
+	return it.current
+}
+
+// ProductSet returns the Cartesian product of the sets as a Set of Tuples. Its
+// size is the product of the sizes of the sets, so for large products
+// Product should be preferred.
+func ProductSet(sets ...Set) Set {
+	s := NewSet()
+	s.elementsType = reflect.TypeOf(Tuple{})
+
+	for it := Product(sets...); it.Next(); {
+		s.Add(it.Tuple())
+	}
+
+	return s
+}
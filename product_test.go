@@ -0,0 +1,77 @@
+package set
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProduct(t *testing.T) {
+	oses := CreateSet("linux")
+	oses.Add("darwin")
+	arches := CreateSet("amd64")
+	arches.Add("arm64")
+	versions := CreateSet(20)
+	versions.AddAll(21, 22)
+
+	var got []string
+	for it := Product(oses, arches, versions); it.Next(); {
+		got = append(got, it.Tuple().String())
+	}
+
+	if len(got) != 12 || got[0] != "(darwin, amd64, 20)" || got[11] != "(linux, arm64, 22)" {
+		t.Errorf("The product of %v, %v and %v is %v.", oses, arches, versions, got)
+	}
+
+	tuple := NewTuple("linux", "arm64", 21)
+	product := ProductSet(oses, arches, versions)
+	if product.Length() != 12 || !product.Has(tuple) || product.Has(NewTuple("linux", "arm64", int64(21))) {
+		t.Errorf("The product set of %v, %v and %v is wrong.", oses, arches, versions)
+	}
+
+	want := []reflect.Type{reflect.TypeOf(""), reflect.TypeOf(""), reflect.TypeOf(0)}
+	if !reflect.DeepEqual(tuple.Types(), want) {
+		t.Errorf("The types of %v are %v, instead of %v.", tuple, tuple.Types(), want)
+	}
+}
+
+func TestProductEdgeCases(t *testing.T) {
+	if it := Product(CreateSet(1), NewSet()); it.Next() {
+		t.Errorf("The product with the empty set is not empty.")
+	}
+
+	it := Product()
+	if !it.Next() || it.Tuple().Len() != 0 || it.Next() {
+		t.Errorf("The product of no sets is not a single empty tuple.")
+	}
+
+	mixed := NewSet()
+	mixed.AddAll(1, "a")
+	product := ProductSet(mixed, CreateSet(true))
+	for elem := range product.Set {
+		tuple := elem.(Tuple)
+		if tuple.Types()[0] != nil || tuple.Types()[1] != reflect.TypeOf(true) {
+			t.Errorf("The types of %v are %v.", tuple, tuple.Types())
+		}
+	}
+
+	if product.Length() != 2 {
+		t.Errorf("The product of %v and {true} is %v.", mixed, product)
+	}
+
+	// Elements added before the type of a set was set are left out.
+	late := NewSet()
+	late.Add("a")
+	late.SetType(1)
+	late.Add(2)
+	product = ProductSet(late, CreateSet(true))
+	if product.Length() != 1 || !product.Has(NewTuple(2, true)) {
+		t.Errorf("The product of %v and {true} is %v.", late, product)
+	}
+
+	foreign := NewSet()
+	foreign.Add("a")
+	foreign.SetType(1)
+	if it := Product(CreateSet(1), foreign); it.Next() {
+		t.Errorf("The product with %v, which has no elements of its type, is not empty.", foreign)
+	}
+}
@@ -0,0 +1,67 @@
+package set
+
+import (
+	"reflect"
+)
+
+// ReadOnlySet is the part of the methods of Set that do not modify it. *Set
+// implements it, so a function that takes a ReadOnlySet accepts any set, but
+// a caller who wants to be sure the set is not modified, e.g. through a type
+// assertion, should pass the result of View instead.
+type ReadOnlySet interface {
+	Has(elem interface{}) bool
+	Length() int
+	Empty() bool
+	Each(fn func(elem interface{}) bool)
+	ElementsType() reflect.Type
+	Fingerprint() Fingerprint
+	SameType(s2 Set) bool
+	Equal(s2 Set) bool
+	Subset(s2 Set) bool
+	Union(s2 Set) (Set, error)
+	Intersection(s2 Set) (Set, error)
+	Difference(s2 Set) (Set, error)
+	Copy() Set
+}
+
+// readOnlyView is the ReadOnlySet returned by View. It hides the set behind an
+// unexported field, so neither the map Set.Set nor the methods that modify
+// the set can be reached from it.
+type readOnlyView struct {
+	s *Set
+}
+
+// View returns a read-only view of the set s. Creating the view is O(1), and
+// it reflects every later change of s. Has, Length, Empty, Each, ElementsType,
+// Fingerprint, SameType, Equal and Subset read s in place, without copying;
+// Union, Intersection, Difference and Copy return new sets, with copies of the
+// elements, that the caller is free to modify.
+func (s *Set) View() ReadOnlySet {
+	return readOnlyView{s}
+}
+
+func (v readOnlyView) Has(elem interface{}) bool { return v.s.Has(elem) }
+
+func (v readOnlyView) Length() int { return v.s.Length() }
+
+func (v readOnlyView) Empty() bool { return v.s.Empty() }
+
+func (v readOnlyView) Each(fn func(elem interface{}) bool) { v.s.Each(fn) }
+
+func (v readOnlyView) ElementsType() reflect.Type { return v.s.ElementsType() }
+
+func (v readOnlyView) Fingerprint() Fingerprint { return v.s.Fingerprint() }
+
+func (v readOnlyView) SameType(s2 Set) bool { return v.s.SameType(s2) }
+
+func (v readOnlyView) Equal(s2 Set) bool { return v.s.Equal(s2) }
+
+func (v readOnlyView) Subset(s2 Set) bool { return v.s.Subset(s2) }
+
+func (v readOnlyView) Union(s2 Set) (Set, error) { return v.s.Union(s2) }
+
+func (v readOnlyView) Intersection(s2 Set) (Set, error) { return v.s.Intersection(s2) }
+
+func (v readOnlyView) Difference(s2 Set) (Set, error) { return v.s.Difference(s2) }
+
+func (v readOnlyView) Copy() Set { return v.s.Copy() }
@@ -0,0 +1,37 @@
+package set
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestView(t *testing.T) {
+	s := CreateSet(1)
+	v := s.View()
+
+	if _, ok := v.(*Set); ok {
+		t.Errorf("The view of %v can be turned back into a *Set.", s)
+	}
+
+	if !v.Has(1) || v.Length() != 1 || v.ElementsType() != reflect.TypeOf(1) {
+		t.Errorf("The view of %v does not reflect it.", s)
+	}
+
+	s.Add(2)
+	if !v.Has(2) || v.Fingerprint() != s.Fingerprint() {
+		t.Errorf("The view did not see the change of %v.", s)
+	}
+
+	c := v.Copy()
+	c.Add(3)
+	if v.Has(3) || s.Has(3) {
+		t.Errorf("Modifying the copy of the view modified %v.", s)
+	}
+
+	union, err := v.Union(CreateSet(4))
+	if err != nil || union.Length() != 3 {
+		t.Errorf("The union through the view resulted in %v.", union)
+	}
+
+	var _ ReadOnlySet = &s
+}
@@ -0,0 +1,57 @@
+package set
+
+import (
+	"encoding/gob"
+	"fmt"
+	"reflect"
+)
+
+// registry maps the names written in encoded sets back to the types of their
+// elements. It is filled with the predeclared types in init and with any other
+// type through RegisterType.
+var registry = make(map[string]reflect.Type)
+
+func init() {
+	for _, elem := range []interface{}{
+		false, "",
+		int(0), int8(0), int16(0), int32(0), int64(0),
+		uint(0), uint8(0), uint16(0), uint32(0), uint64(0), uintptr(0),
+		float32(0), float64(0), complex64(0), complex128(0),
+	} {
+		RegisterType(elem)
+	}
+}
+
+// RegisterType records the type of elem, so that encoded elements of that type
+// can be decoded again. The predeclared types are always registered; any
+// other type has to be registered, in every process that decodes it, before
+// decoding. Only exported fields of struct types survive the JSON encoding.
+func RegisterType(elem interface{}) {
+	t := reflect.TypeOf(elem)
+	registry[typeName(t)] = t
+	gob.Register(elem)
+}
+
+// lookupType returns the registered type with the given name. The empty name
+// stands for the nil type of sets that accept elements of any type.
+func lookupType(name string) (reflect.Type, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	t, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("set: type %q is not registered", name)
+	}
+
+	return t, nil
+}
+
+// nameOf is the inverse of lookupType.
+func nameOf(t reflect.Type) string {
+	if t == nil {
+		return ""
+	}
+
+	return typeName(t)
+}
@@ -0,0 +1,459 @@
+package set
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrNotHomogeneous is returned by operations that need a relation whose
+// domain and codomain are the same set.
+var ErrNotHomogeneous = errors.New("set: the domain and the codomain of the relation differ")
+
+// ErrNotEquivalence is returned when a relation that must be an equivalence
+// relation is not reflexive, symmetric and transitive.
+var ErrNotEquivalence = errors.New("set: the relation is not an equivalence relation")
+
+// Pair is an ordered pair of elements. It is hashable if both elements are.
+type Pair struct {
+	First, Second interface{}
+}
+
+// String returns the pair in the form (a, b).
+func (p Pair) String() string {
+	return fmt.Sprintf("(%v, %v)", p.First, p.Second)
+}
+
+// Relation is a binary relation from a domain Set to a codomain Set: a set of
+// pairs whose first element is in the domain and whose second element is in
+// the codomain. Relations whose domain and codomain are the same set are
+// called homogeneous; only they have closures.
+type Relation struct {
+	domain   Set
+	codomain Set
+	// succ maps every element of the domain to the elements it is related
+	// to, and pred every element of the codomain to those related to it.
+	succ map[interface{}]map[interface{}]struct{}
+	pred map[interface{}]map[interface{}]struct{}
+	n    int
+}
+
+// NewRelation allocates memory for a new, empty Relation from a copy of domain
+// to a copy of codomain.
+func NewRelation(domain, codomain Set) (r Relation) {
+	r.domain = domain.Copy()
+	r.codomain = codomain.Copy()
+	r.succ = make(map[interface{}]map[interface{}]struct{})
+	r.pred = make(map[interface{}]map[interface{}]struct{})
+
+	return r
+}
+
+// Domain returns a copy of the domain of the relation r.
+func (r *Relation) Domain() Set {
+	return r.domain.Copy()
+}
+
+// Codomain returns a copy of the codomain of the relation r.
+func (r *Relation) Codomain() Set {
+	return r.codomain.Copy()
+}
+
+// ElementsTypes returns the types of the domain and the codomain of the
+// relation r.
+func (r *Relation) ElementsTypes() (domain, codomain reflect.Type) {
+	return r.domain.elementsType, r.codomain.elementsType
+}
+
+// Add relates a to b. It returns true if they were not related already. If a
+// is not in the domain or b is not in the codomain of r, nothing is added and
+// a DomainError with the offending element is returned.
+func (r *Relation) Add(a, b interface{}) (bool, error) {
+	if !r.domain.Has(a) {
+		return false, &DomainError{Elem: a}
+	}
+
+	if !r.codomain.Has(b) {
+		return false, &DomainError{Elem: b}
+	}
+
+	if r.Has(a, b) {
+		return false, nil
+	}
+
+	if r.succ[a] == nil {
+		r.succ[a] = make(map[interface{}]struct{})
+	}
+	if r.pred[b] == nil {
+		r.pred[b] = make(map[interface{}]struct{})
+	}
+
+	r.succ[a][b] = exists
+	r.pred[b][a] = exists
+	r.n++
+
+	return true, nil
+}
+
+// Remove removes the pair (a, b) from the relation r. It returns true if a was
+// related to b.
+func (r *Relation) Remove(a, b interface{}) bool {
+	if !r.Has(a, b) {
+		return false
+	}
+
+	delete(r.succ[a], b)
+	if len(r.succ[a]) == 0 {
+		delete(r.succ, a)
+	}
+
+	delete(r.pred[b], a)
+	if len(r.pred[b]) == 0 {
+		delete(r.pred, b)
+	}
+
+	r.n--
+
+	return true
+}
+
+// Has returns true if a is related to b.
+func (r *Relation) Has(a, b interface{}) bool {
+	_, ok := r.succ[a][b]
+
+	return ok
+}
+
+// Length returns the number of pairs in the relation r.
+func (r *Relation) Length() int {
+	return r.n
+}
+
+// Each calls fn for every pair of the relation r, in no particular order,
+// until fn returns false.
+func (r *Relation) Each(fn func(a, b interface{}) bool) {
+	for a, bs := range r.succ {
+		for b := range bs {
+			if !fn(a, b) {
+				return
+			}
+		}
+	}
+}
+
+// Pairs returns the pairs of the relation r, ordered by their first and then
+// by their second element, with NaturalOrder.
+func (r *Relation) Pairs() []Pair {
+	pairs := make([]Pair, 0, r.n)
+	r.Each(func(a, b interface{}) bool {
+		pairs = append(pairs, Pair{a, b})
+		return true
+	})
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if c := NaturalOrder(pairs[i].First, pairs[j].First); c != 0 {
+			return c < 0
+		}
+		return NaturalOrder(pairs[i].Second, pairs[j].Second) < 0
+	})
+
+	return pairs
+}
+
+// String returns the pairs of the relation r in the form {(a, b), (c, d)}.
+func (r *Relation) String() string {
+	pairs := r.Pairs()
+	elems := make([]string, len(pairs))
+	for i, p := range pairs {
+		elems[i] = p.String()
+	}
+
+	return "{" + strings.Join(elems, ", ") + "}"
+}
+
+// Homogeneous returns true if the domain and the codomain of the relation r are
+// the same set.
+func (r *Relation) Homogeneous() bool {
+	return r.domain.SameType(r.codomain) && r.domain.Equal(r.codomain)
+}
+
+// Reflexive returns true if the relation r is homogeneous and every element of
+// its domain is related to itself.
+func (r *Relation) Reflexive() bool {
+	if !r.Homogeneous() {
+		return false
+	}
+
+	for a := range r.domain.Set {
+		if !r.Has(a, a) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Symmetric returns true if b is related to a whenever a is related to b.
+func (r *Relation) Symmetric() bool {
+	symmetric := true
+	r.Each(func(a, b interface{}) bool {
+		symmetric = r.Has(b, a)
+		return symmetric
+	})
+
+	return symmetric
+}
+
+// Antisymmetric returns true if a and b are never related to each other both
+// ways, unless they are the same element.
+func (r *Relation) Antisymmetric() bool {
+	antisymmetric := true
+	r.Each(func(a, b interface{}) bool {
+		antisymmetric = a == b || !r.Has(b, a)
+		return antisymmetric
+	})
+
+	return antisymmetric
+}
+
+// Transitive returns true if a is related to c whenever a is related to some b
+// that is related to c.
+func (r *Relation) Transitive() bool {
+	for a, bs := range r.succ {
+		for b := range bs {
+			for c := range r.succ[b] {
+				if !r.Has(a, c) {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// Functional returns true if every element of the domain is related to at most
+// one element of the codomain, so that the relation r is a partial function.
+func (r *Relation) Functional() bool {
+	for _, bs := range r.succ {
+		if len(bs) > 1 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equivalence returns true if the relation r is reflexive, symmetric and
+// transitive.
+func (r *Relation) Equivalence() bool {
+	return r.Reflexive() && r.Symmetric() && r.Transitive()
+}
+
+// copy returns a new relation with the domain, the codomain and the pairs of
+// the relation r.
+func (r *Relation) copy() Relation {
+	c := NewRelation(r.domain, r.codomain)
+	r.Each(func(a, b interface{}) bool {
+		c.Add(a, b)
+		return true
+	})
+
+	return c
+}
+
+// ReflexiveClosure returns the smallest reflexive relation that contains the
+// relation r. If r is not homogeneous, ErrNotHomogeneous is returned.
+func (r *Relation) ReflexiveClosure() (Relation, error) {
+	if !r.Homogeneous() {
+		return Relation{}, ErrNotHomogeneous
+	}
+
+	c := r.copy()
+	for a := range c.domain.Set {
+		c.Add(a, a)
+	}
+
+	return c, nil
+}
+
+// SymmetricClosure returns the smallest symmetric relation that contains the
+// relation r. If r is not homogeneous, ErrNotHomogeneous is returned.
+func (r *Relation) SymmetricClosure() (Relation, error) {
+	if !r.Homogeneous() {
+		return Relation{}, ErrNotHomogeneous
+	}
+
+	c := r.copy()
+	r.Each(func(a, b interface{}) bool {
+		c.Add(b, a)
+		return true
+	})
+
+	return c, nil
+}
+
+// TransitiveClosure returns the smallest transitive relation that contains the
+// relation r: a is related to c if there is a path from a to c in r. If r is
+// not homogeneous, ErrNotHomogeneous is returned. It takes O(n·(n+m)) time,
+// for n elements and m pairs.
+func (r *Relation) TransitiveClosure() (Relation, error) {
+	if !r.Homogeneous() {
+		return Relation{}, ErrNotHomogeneous
+	}
+
+	c := NewRelation(r.domain, r.codomain)
+	for a := range r.succ {
+		// Every element reachable from a, found breadth-first.
+		var queue []interface{}
+		for b := range r.succ[a] {
+			if ok, _ := c.Add(a, b); ok {
+				queue = append(queue, b)
+			}
+		}
+
+		for len(queue) > 0 {
+			b := queue[0]
+			queue = queue[1:]
+
+			for next := range r.succ[b] {
+				if ok, _ := c.Add(a, next); ok {
+					queue = append(queue, next)
+				}
+			}
+		}
+	}
+
+	return c, nil
+}
+
+// EquivalenceClosure returns the smallest equivalence relation that contains
+// the relation r. If r is not homogeneous, ErrNotHomogeneous is returned.
+func (r *Relation) EquivalenceClosure() (Relation, error) {
+	if !r.Homogeneous() {
+		return Relation{}, ErrNotHomogeneous
+	}
+
+	// The classes of the closure are the connected components of r.
+	d := NewDisjointSets()
+	d.elementsType = r.domain.elementsType
+	for a := range r.domain.Set {
+		d.Add(a)
+	}
+
+	r.Each(func(a, b interface{}) bool {
+		d.Union(a, b)
+		return true
+	})
+
+	c := NewRelation(r.domain, r.codomain)
+	for _, b := range d.Components() {
+		for a := range b.Set {
+			for other := range b.Set {
+				c.Add(a, other)
+			}
+		}
+	}
+
+	return c, nil
+}
+
+// Inverse returns the inverse of the relation r, from its codomain to its
+// domain: b is related to a in the inverse if a is related to b in r.
+func (r *Relation) Inverse() Relation {
+	inv := NewRelation(r.codomain, r.domain)
+	r.Each(func(a, b interface{}) bool {
+		inv.Add(b, a)
+		return true
+	})
+
+	return inv
+}
+
+// Compose returns the composition of the relations r1 and r2, from the domain
+// of r1 to the codomain of r2: a is related to c if a is related to some b in
+// r1 that is related to c in r2. If the codomain of r1 and the domain of r2
+// are of different types, a TypeError is returned.
+func (r1 *Relation) Compose(r2 Relation) (Relation, error) {
+	if !r1.codomain.SameType(r2.domain) {
+		return Relation{}, &TypeError{r1.codomain.elementsType, r2.domain.elementsType,
+			"The relations' types do not match."}
+	}
+
+	c := NewRelation(r1.domain, r2.codomain)
+	r1.Each(func(a, b interface{}) bool {
+		for next := range r2.succ[b] {
+			c.Add(a, next)
+		}
+		return true
+	})
+
+	return c, nil
+}
+
+// related returns the set of the elements of to that rel maps the elements of s
+// to. The elements of s must be in from, otherwise a DomainError is returned.
+func related(s, from, to Set, rel map[interface{}]map[interface{}]struct{}) (Set, error) {
+	if !s.Empty() && !s.SameType(from) {
+		return Set{}, &TypeError{from.elementsType, s.elementsType,
+			"The sets' types do not match."}
+	}
+
+	result := NewSet()
+	result.elementsType = to.elementsType
+
+	for a := range s.Set {
+		if !from.Has(a) {
+			return Set{}, &DomainError{Elem: a}
+		}
+
+		for b := range rel[a] {
+			result.Add(b)
+		}
+	}
+
+	return result, nil
+}
+
+// Image returns the set of the elements of the codomain that the elements of
+// s are related to. Every element of s must be in the domain of the relation
+// r, otherwise a DomainError is returned.
+func (r *Relation) Image(s Set) (Set, error) {
+	return related(s, r.domain, r.codomain, r.succ)
+}
+
+// PreImage returns the set of the elements of the domain that are related to
+// the elements of s. Every element of s must be in the codomain of the
+// relation r, otherwise a DomainError is returned.
+func (r *Relation) PreImage(s Set) (Set, error) {
+	return related(s, r.codomain, r.domain, r.pred)
+}
+
+// Partition returns the equivalence classes of the relation r. If r is not an
+// equivalence relation, ErrNotEquivalence is returned.
+func (r *Relation) Partition() (Partition, error) {
+	if !r.Equivalence() {
+		return Partition{}, ErrNotEquivalence
+	}
+
+	return NewPartition(r.domain, r.Has), nil
+}
+
+// RelationOf returns the equivalence relation whose classes are the blocks of
+// the partition p, over the partitioned set.
+func RelationOf(p Partition) Relation {
+	s := p.Set()
+	r := NewRelation(s, s)
+
+	for _, b := range p.blocks {
+		for _, a := range b {
+			for _, c := range b {
+				r.Add(a, c)
+			}
+		}
+	}
+
+	return r
+}
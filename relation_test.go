@@ -0,0 +1,191 @@
+package set
+
+import (
+	"testing"
+)
+
+// services returns the homogeneous relation "depends-on" over a few services.
+func services(t *testing.T) Relation {
+	s := CreateSet("api")
+	s.AddAll("auth", "db", "cache", "web")
+
+	r := NewRelation(s, s)
+	for _, p := range []Pair{{"web", "api"}, {"api", "auth"}, {"api", "cache"}, {"auth", "db"}} {
+		if _, err := r.Add(p.First, p.Second); err != nil {
+			t.Fatalf("Adding %v returned %v.", p, err)
+		}
+	}
+
+	return r
+}
+
+func TestRelationAdd(t *testing.T) {
+	r := services(t)
+
+	if ok, err := r.Add("web", "api"); ok || err != nil {
+		t.Errorf("Adding an existing pair returned %v, %v.", ok, err)
+	}
+
+	if _, err := r.Add("web", "queue"); err == nil {
+		t.Errorf("A pair outside the codomain was added.")
+	} else if e, ok := err.(*DomainError); !ok || e.Elem != "queue" {
+		t.Errorf("Adding a pair outside the codomain returned %v.", err)
+	}
+
+	if r.Length() != 4 || !r.Has("api", "auth") || r.Has("auth", "api") {
+		t.Errorf("The relation %v has the wrong pairs.", r.String())
+	}
+
+	if !r.Remove("api", "cache") || r.Remove("api", "cache") || r.Length() != 3 {
+		t.Errorf("The pair (api, cache) was not removed from %v.", r.String())
+	}
+
+	want := "{(api, auth), (auth, db), (web, api)}"
+	if r.String() != want {
+		t.Errorf("The relation is %v, instead of %v.", r.String(), want)
+	}
+}
+
+func TestRelationProperties(t *testing.T) {
+	r := services(t)
+
+	if r.Reflexive() || r.Symmetric() || !r.Antisymmetric() || r.Transitive() || r.Functional() {
+		t.Errorf("The relation %v has the wrong properties.", r.String())
+	}
+
+	closure, err := r.TransitiveClosure()
+	if err != nil || closure.Length() != 8 || !closure.Has("web", "db") || !closure.Transitive() {
+		t.Errorf("The transitive closure of %v is %v, %v.", r.String(), closure.String(), err)
+	}
+
+	reflexive, _ := r.ReflexiveClosure()
+	symmetric, _ := r.SymmetricClosure()
+	if !reflexive.Reflexive() || reflexive.Length() != 9 || !symmetric.Symmetric() || symmetric.Length() != 8 {
+		t.Errorf("The closures of %v are %v and %v.", r.String(), reflexive.String(), symmetric.String())
+	}
+
+	equivalence, err := r.EquivalenceClosure()
+	if err != nil || !equivalence.Equivalence() || equivalence.Length() != 25 {
+		t.Errorf("The equivalence closure of %v is %v, %v.", r.String(), equivalence.String(), err)
+	}
+
+	inverse := r.Inverse()
+	if !inverse.Has("db", "auth") || inverse.Has("auth", "db") || inverse.Length() != r.Length() {
+		t.Errorf("The inverse of %v is %v.", r.String(), inverse.String())
+	}
+}
+
+func TestRelationHeterogeneous(t *testing.T) {
+	users := CreateSet("alice")
+	users.Add("bob")
+	roles := CreateSet(1)
+	roles.AddAll(2, 3)
+
+	r := NewRelation(users, roles)
+	r.Add("alice", 1)
+	r.Add("bob", 2)
+
+	if r.Homogeneous() || r.Reflexive() || !r.Functional() {
+		t.Errorf("The relation %v has the wrong properties.", r.String())
+	}
+
+	if _, err := r.TransitiveClosure(); err != ErrNotHomogeneous {
+		t.Errorf("The transitive closure of %v returned %v.", r.String(), err)
+	}
+
+	if _, err := r.Partition(); err != ErrNotEquivalence {
+		t.Errorf("The partition of %v returned %v.", r.String(), err)
+	}
+
+	d, c := r.ElementsTypes()
+	if d != users.ElementsType() || c != roles.ElementsType() {
+		t.Errorf("The types of %v are %v and %v.", r.String(), d, c)
+	}
+}
+
+func TestCompose(t *testing.T) {
+	users := CreateSet("alice")
+	users.Add("bob")
+	roles := CreateSet("admin")
+	roles.Add("viewer")
+	perms := CreateSet("read")
+	perms.Add("write")
+
+	hasRole := NewRelation(users, roles)
+	hasRole.Add("alice", "admin")
+	hasRole.Add("bob", "viewer")
+
+	grants := NewRelation(roles, perms)
+	grants.Add("admin", "read")
+	grants.Add("admin", "write")
+	grants.Add("viewer", "read")
+
+	canAccess, err := hasRole.Compose(grants)
+	want := "{(alice, read), (alice, write), (bob, read)}"
+	if err != nil || canAccess.String() != want {
+		t.Errorf("The composition is %v, %v, instead of %v.", canAccess.String(), err, want)
+	}
+
+	levels := CreateSet(1)
+	if _, err := hasRole.Compose(NewRelation(levels, perms)); err == nil {
+		t.Errorf("Relations of different types were composed.")
+	}
+
+	bob := CreateSet("bob")
+	image, err := canAccess.Image(bob)
+	if err != nil || image.Length() != 1 || !image.Has("read") {
+		t.Errorf("The image of %v is %v, %v.", bob, image, err)
+	}
+
+	read := CreateSet("read")
+	preImage, err := canAccess.PreImage(read)
+	if err != nil || preImage.Length() != 2 {
+		t.Errorf("The preimage of %v is %v, %v.", read, preImage, err)
+	}
+
+	carol := CreateSet("carol")
+	if _, err := canAccess.Image(carol); err == nil {
+		t.Errorf("The image of %v, outside the domain, returned no error.", carol)
+	}
+}
+
+func TestEquivalenceClosureTypes(t *testing.T) {
+	u, _ := NewUniverse(1, 2, 3, 4)
+	s := u.Set()
+	s.AddAll(1, 2, 3, 4)
+
+	r := NewRelation(s, s)
+	r.Add(1, 2)
+	r.Add(3, 4)
+
+	closure, err := r.EquivalenceClosure()
+	d, c := closure.ElementsTypes()
+	if err != nil || d != s.ElementsType() || c != s.ElementsType() {
+		t.Errorf("The types of the closure of %v are %v and %v.", r.String(), d, c)
+	}
+
+	if domain := closure.Domain(); domain.Domain() != u {
+		t.Errorf("The domain of the closure of %v lost its universe.", r.String())
+	}
+
+	composed, err := r.Compose(closure)
+	if err != nil || !composed.Has(1, 1) || composed.Length() != 4 {
+		t.Errorf("The composition of %v with its closure is %v, %v.", r.String(), composed.String(), err)
+	}
+}
+
+func TestRelationPartition(t *testing.T) {
+	s := CreateSet(0)
+	s.AddAll(1, 2, 3, 4, 5)
+
+	p := NewPartition(s, sameThird)
+	r := RelationOf(p)
+	if !r.Equivalence() || r.Length() != 12 {
+		t.Errorf("The relation of %v is %v.", p.String(), r.String())
+	}
+
+	back, err := r.Partition()
+	if err != nil || !back.Equal(p) {
+		t.Errorf("The partition of %v is %v, instead of %v.", r.String(), back.String(), p.String())
+	}
+}
@@ -15,7 +15,6 @@ whatever performance penalties this might have.
 This set implementation is not thread-safe.
 */
 
-// TODO: Make add take variadic arguments?
 package set
 
 import (
@@ -42,6 +41,9 @@ func (e *TypeError) Error() string {
 type Set struct {
 	Set          map[interface{}]struct{}
 	elementsType reflect.Type
+	digest       *digest
+	observers    *observers
+	domain       *Universe
 }
 
 var exists = struct{}{}
@@ -51,6 +53,8 @@ var exists = struct{}{}
 func NewSet() (s Set) {
 	s.Set = make(map[interface{}]struct{})
 	s.elementsType = nil
+	s.digest = &digest{}
+	s.observers = &observers{}
 
 	return s
 }
@@ -77,12 +81,35 @@ func (s *Set) SetType(elem interface{}) error {
 func CreateSet(elem interface{}) (s Set) {
 	s.Set = make(map[interface{}]struct{})
 	s.elementsType = reflect.ValueOf(elem).Type()
+	s.digest = &digest{}
+	s.observers = &observers{}
 
 	s.Add(elem)
 
 	return s
 }
 
+// ElementsType returns the type of the elements the set accepts, or nil if it
+// accepts elements of any type.
+func (s *Set) ElementsType() reflect.Type {
+	return s.elementsType
+}
+
+// Copy returns a new set with the elements, the type and the universe of the
+// set s. Unlike assigning s to another variable, the copy does not share its
+// elements, or its observers, with s.
+func (s *Set) Copy() Set {
+	c := NewSet()
+	c.elementsType = s.elementsType
+	c.domain = s.domain
+
+	for elem := range s.Set {
+		c.Add(elem)
+	}
+
+	return c
+}
+
 // properType checks if elem is the same type as Set.elementsType.
 func (s *Set) properType(elem interface{}) bool {
 	if s.elementsType == nil || reflect.ValueOf(elem).Type() == s.elementsType {
@@ -104,15 +131,18 @@ func (s1 *Set) SameType(s2 Set) bool {
 
 // Add adds elem to the set s. If the element exists in the set or if the
 // element is not of the correct type,, no addition is performed and false is
-// returned. Otherwise, a new entry is added and it retuns true.
+// returned. Otherwise, a new entry is added and it retuns true. If the set is
+// bound to a Universe, elements outside of it are rejected as well.
 func (s *Set) Add(elem interface{}) bool {
-	if !s.properType(elem) {
+	if !s.properType(elem) || !s.inDomain(elem) {
 		return false
 	}
 
 	// bool defaults to false, so , if an element does not exist, it will map to false.
 	if _, ok := s.Set[elem]; !ok {
 		s.Set[elem] = exists
+		s.digest.add(elem)
+		s.observers.added(elem)
 
 		return true
 	}
@@ -120,6 +150,74 @@ func (s *Set) Add(elem interface{}) bool {
 	return false
 }
 
+// Remove removes elem from the set s. If the element does not exist in the
+// set or if it is not of the correct type, nothing is removed and false is
+// returned. Otherwise, the element is removed and it returns true.
+func (s *Set) Remove(elem interface{}) bool {
+	if !s.properType(elem) {
+		return false
+	}
+
+	if _, ok := s.Set[elem]; !ok {
+		return false
+	}
+
+	delete(s.Set, elem)
+	s.digest.remove(elem)
+	s.observers.removed(elem)
+
+	return true
+}
+
+// AddAll adds every element of elems to the set s, as Add does, and returns the
+// number of elements that were actually added. Observers are notified once for
+// the whole operation.
+func (s *Set) AddAll(elems ...interface{}) int {
+	defer s.observers.batch()()
+
+	n := 0
+	for _, elem := range elems {
+		if s.Add(elem) {
+			n++
+		}
+	}
+
+	return n
+}
+
+// RemoveAll removes every element of elems from the set s, as Remove does, and
+// returns the number of elements that were actually removed. Observers are
+// notified once for the whole operation.
+func (s *Set) RemoveAll(elems ...interface{}) int {
+	defer s.observers.batch()()
+
+	n := 0
+	for _, elem := range elems {
+		if s.Remove(elem) {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Clear removes every element from the set s. The type of the set, if any, is
+// kept.
+func (s *Set) Clear() {
+	if s.Empty() {
+		return
+	}
+
+	for elem := range s.Set {
+		delete(s.Set, elem)
+	}
+
+	if s.digest != nil {
+		*s.digest = digest{tracking: s.digest.tracking}
+	}
+	s.observers.cleared()
+}
+
 // Has returns true if the element provided already exists in the set, otherwise false.
 func (s *Set) Has(elem interface{}) bool {
 	if !s.properType(elem) {
@@ -135,6 +233,16 @@ func (s *Set) Length() int {
 	return len(s.Set)
 }
 
+// Each calls fn for every element of the set s, in no particular order, until
+// fn returns false.
+func (s *Set) Each(fn func(elem interface{}) bool) {
+	for elem := range s.Set {
+		if !fn(elem) {
+			return
+		}
+	}
+}
+
 // Empty returns true if the set is empty, if it has no elements, otherwise
 // false.
 func (s *Set) Empty() bool {
@@ -192,11 +300,10 @@ func (s1 *Set) Subset(s2 Set) bool {
 		i++
 	}
 
-	for c := range s {
+	for _, found := range s {
 		// If some element of s is false, that means an element of s1
-		// was not found in s2, so s1 is not a subset of s2. false is an
-		// 'untyped boolean', hence the need to use 0.
-		if c != 0 {
+		// was not found in s2, so s1 is not a subset of s2.
+		if !found {
 			return false
 		}
 	}
@@ -204,7 +311,9 @@ func (s1 *Set) Subset(s2 Set) bool {
 	return true
 }
 
-// Union returns the union of the two sets.
+// Union returns the union of the two sets. If s1 is bound to a Universe, so is
+// the union, and every element of s2 must belong to it, otherwise a
+// DomainError is returned.
 func (s1 *Set) Union(s2 Set) (Set, error) {
 	// An empty set will have nil elementsType which will trigger this
 	// clause. But it's a valid operation to make the union of a set with
@@ -216,8 +325,18 @@ func (s1 *Set) Union(s2 Set) (Set, error) {
 		}
 	}
 
+	// The union stays in the universe of s1 only if s2 does as well.
+	if s1.domain != nil {
+		for v := range s2.Set {
+			if !s1.domain.Has(v) {
+				return Set{}, &DomainError{Elem: v}
+			}
+		}
+	}
+
 	s := NewSet()
 	s.elementsType = s1.elementsType
+	s.domain = s1.domain
 
 	for v := range s1.Set {
 		s.Add(v)
@@ -230,7 +349,8 @@ func (s1 *Set) Union(s2 Set) (Set, error) {
 	return s, nil
 }
 
-// Intersection returns the intersection of the two sets.
+// Intersection returns the intersection of the two sets. It is bound to the
+// Universe of s1, if any.
 func (s1 *Set) Intersection(s2 Set) (Set, error) {
         // An empty set will have nil elementsType which will trigger this
 	// clause. But it's a valid operation to make the union of a set with
@@ -244,6 +364,7 @@ func (s1 *Set) Intersection(s2 Set) (Set, error) {
 
 	s := NewSet()
 	s.elementsType = s1.elementsType
+	s.domain = s1.domain
 
 	for v1 := range s1.Set {
 		for v2 := range s2.Set {
@@ -257,7 +378,8 @@ func (s1 *Set) Intersection(s2 Set) (Set, error) {
 }
 
 // Difference returns a set that is the difference (also termed as relative
-// complement) of s1 from s2. The resulting set is the s1\s2.
+// complement) of s1 from s2. The resulting set is the s1\s2. It is bound to the
+// Universe of s1, if any.
 func (s1 *Set) Difference(s2 Set) (Set, error) {
 	if !s1.SameType(s2) {
 		return Set{}, &TypeError{s1.elementsType, s2.elementsType,
@@ -265,6 +387,7 @@ func (s1 *Set) Difference(s2 Set) (Set, error) {
 	}
 
 	s := NewSet()
+	s.domain = s1.domain
 
 	for v1 := range s1.Set {
 		if !s2.Has(v1) {
@@ -674,3 +674,82 @@ func TestDifference(t *testing.T) {
 		t.Errorf("The difference of set %v from set %v is %v instead of %v.", s1, s2, got, want)
 	}
 }
+
+func TestRemove(t *testing.T) {
+	s := CreateSet(1)
+	s.Add(2)
+
+	if !s.Remove(1) {
+		t.Errorf("1 was not removed from the set %v", s)
+	}
+
+	if s.Has(1) {
+		t.Errorf("1 is still in the set %v", s)
+	}
+
+	if s.Remove(1) {
+		t.Errorf("1 was removed twice from the set %v", s)
+	}
+
+	if s.Remove("2") {
+		t.Errorf("\"2\" was removed from the set %v", s)
+	}
+
+	if s.Length() != 1 {
+		t.Errorf("The set %v does not have exactly one element.", s)
+	}
+}
+
+func TestAddAllRemoveAll(t *testing.T) {
+	s := CreateSet(1)
+
+	if n := s.AddAll(1, 2, 3, "4"); n != 2 {
+		t.Errorf("AddAll added %d elements in the set %v, instead of 2.", n, s)
+	}
+
+	if n := s.RemoveAll(1, 2, 5); n != 2 {
+		t.Errorf("RemoveAll removed %d elements from the set %v, instead of 2.", n, s)
+	}
+
+	if !s.Equal(CreateSet(3)) {
+		t.Errorf("The set %v is not equal to the set %v.", s, CreateSet(3))
+	}
+}
+
+func TestClear(t *testing.T) {
+	s := CreateSet(1)
+	s.Add(2)
+	s.Clear()
+
+	if !s.Empty() {
+		t.Errorf("The set %v is not empty", s)
+	}
+
+	if s.Add("1") {
+		t.Errorf("\"1\" was added in the set %v", s)
+	}
+}
+
+func TestCopy(t *testing.T) {
+	s := CreateSet(1)
+	c := s.Copy()
+	c.Add(2)
+
+	if s.Has(2) {
+		t.Errorf("Modifying the copy %v modified the set %v", c, s)
+	}
+
+	if c.Add("3") {
+		t.Errorf("The copy %v lost the type of the set %v", c, s)
+	}
+}
+
+func TestSubsetDisjoint(t *testing.T) {
+	s1 := CreateSet(6)
+	s2 := CreateSet(1)
+	s2.Add(2)
+
+	if s1.Subset(s2) {
+		t.Errorf("The set %v is a subset of the set %v.", s1, s2)
+	}
+}
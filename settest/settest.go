@@ -0,0 +1,239 @@
+/*
+Package settest implements a conformance test suite for the set
+implementations of package set, and any other implementation of
+set.Collection.
+
+A test of an implementation calls TestCollection with a function that builds a
+collection of ints:
+
+	func TestMySet(t *testing.T) {
+		settest.TestCollection(t, func(elems ...interface{}) set.Collection {
+			s := NewMySet()
+			for _, elem := range elems {
+				s.Add(elem)
+			}
+			return s
+		})
+	}
+*/
+package settest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aakordas/set"
+)
+
+// Maker builds a collection of the ints provided, ignoring duplicates. Every
+// collection it builds must have the same ElementsType, either int or nil.
+type Maker func(elems ...interface{}) set.Collection
+
+// samples are the elements of the collections the laws are checked on.
+var samples = [][]interface{}{
+	{},
+	{1},
+	{1, 2},
+	{2, 3},
+	{1, 2, 3, 4},
+	{3, 4, 5},
+	{6},
+}
+
+// TestCollection checks that the collections built by make behave as sets:
+// membership, length and iteration agree with each other, and the union,
+// intersection and difference of the package, applied to them and to Sets
+// with the same elements, obey the laws of set algebra.
+func TestCollection(t *testing.T, make Maker) {
+	t.Run("Membership", func(t *testing.T) { testMembership(t, make) })
+	t.Run("Each", func(t *testing.T) { testEach(t, make) })
+	t.Run("Laws", func(t *testing.T) { testLaws(t, make) })
+	t.Run("CrossImplementation", func(t *testing.T) { testCross(t, make) })
+}
+
+func testMembership(t *testing.T, make Maker) {
+	empty := make()
+	if !empty.Empty() || empty.Length() != 0 || empty.Has(1) {
+		t.Errorf("The empty collection has elements.")
+	}
+
+	c := make(1, 2, 3, 2)
+	if c.Empty() || c.Length() != 3 {
+		t.Errorf("The collection of 1, 2, 3 and 2 has %d elements, instead of 3.", c.Length())
+	}
+
+	for _, elem := range []interface{}{1, 2, 3} {
+		if !c.Has(elem) {
+			t.Errorf("%v is not in the collection.", elem)
+		}
+	}
+
+	if c.Has(4) || c.Has("1") {
+		t.Errorf("The collection has elements it was not built with.")
+	}
+
+	if typ := c.ElementsType(); typ != nil && typ != reflect.TypeOf(0) {
+		t.Errorf("The type of the collection is %v, instead of int or nil.", typ)
+	}
+}
+
+func testEach(t *testing.T, make Maker) {
+	c := make(1, 2, 3, 4)
+
+	seen := map[interface{}]int{}
+	c.Each(func(elem interface{}) bool {
+		seen[elem]++
+		return true
+	})
+
+	if len(seen) != 4 {
+		t.Errorf("Each yields %d distinct elements, instead of 4.", len(seen))
+	}
+	for elem, n := range seen {
+		if n != 1 || !c.Has(elem) {
+			t.Errorf("Each yields %v %d times.", elem, n)
+		}
+	}
+
+	n := 0
+	c.Each(func(interface{}) bool {
+		n++
+		return n < 2
+	})
+	if n != 2 {
+		t.Errorf("Each went on for %d elements after being stopped at 2.", n)
+	}
+}
+
+// setOf returns a Set with the elements and the type of c.
+func setOf(c set.Collection) set.Set {
+	s := set.NewSet()
+	if typ := c.ElementsType(); typ != nil {
+		s.SetType(reflect.Zero(typ).Interface())
+	}
+
+	c.Each(func(elem interface{}) bool {
+		s.Add(elem)
+		return true
+	})
+
+	return s
+}
+
+type operation func(c1, c2 set.Collection) (set.Set, error)
+
+func apply(t *testing.T, op operation, c1, c2 set.Collection) *set.Set {
+	t.Helper()
+
+	s, err := op(c1, c2)
+	if err != nil {
+		t.Fatalf("There was an error trying to combine %v and %v.\n%v", setOf(c1), setOf(c2), err)
+	}
+
+	return &s
+}
+
+func testLaws(t *testing.T, make Maker) {
+	var cs []set.Collection
+	for _, elems := range samples {
+		cs = append(cs, make(elems...))
+	}
+	empty := make()
+
+	for _, a := range cs {
+		// a ∪ ∅ = a, a ∩ ∅ = ∅, a \ ∅ = a
+		if !set.Equal(apply(t, set.Union, a, empty), a) ||
+			!apply(t, set.Intersection, a, empty).Empty() ||
+			!set.Equal(apply(t, set.Difference, a, empty), a) {
+			t.Errorf("The identities with the empty set do not hold for %v.", setOf(a))
+		}
+
+		// a ∪ a = a ∩ a = a, a \ a = ∅
+		if !set.Equal(apply(t, set.Union, a, a), a) ||
+			!set.Equal(apply(t, set.Intersection, a, a), a) ||
+			!apply(t, set.Difference, a, a).Empty() {
+			t.Errorf("The idempotence laws do not hold for %v.", setOf(a))
+		}
+
+		for _, b := range cs {
+			union := apply(t, set.Union, a, b)
+			intersection := apply(t, set.Intersection, a, b)
+
+			// a ∪ b = b ∪ a, a ∩ b = b ∩ a
+			if !set.Equal(union, apply(t, set.Union, b, a)) ||
+				!set.Equal(intersection, apply(t, set.Intersection, b, a)) {
+				t.Errorf("The commutative laws do not hold for %v and %v.", setOf(a), setOf(b))
+			}
+
+			// a ∩ b ⊆ a ⊆ a ∪ b
+			if !set.Subset(intersection, a) || !set.Subset(a, union) {
+				t.Errorf("The subset relations do not hold for %v and %v.", setOf(a), setOf(b))
+			}
+
+			// a ∪ (a ∩ b) = a ∩ (a ∪ b) = a
+			if !set.Equal(apply(t, set.Union, a, intersection), a) ||
+				!set.Equal(apply(t, set.Intersection, a, union), a) {
+				t.Errorf("The absorption laws do not hold for %v and %v.", setOf(a), setOf(b))
+			}
+
+			// (a \ b) ∪ (a ∩ b) = a, (a \ b) ∩ b = ∅
+			difference := apply(t, set.Difference, a, b)
+			if !set.Equal(apply(t, set.Union, difference, intersection), a) ||
+				!apply(t, set.Intersection, difference, b).Empty() {
+				t.Errorf("The difference laws do not hold for %v and %v.", setOf(a), setOf(b))
+			}
+
+			for _, c := range cs {
+				// a ∩ (b ∪ c) = (a ∩ b) ∪ (a ∩ c)
+				got1 := apply(t, set.Intersection, a, apply(t, set.Union, b, c))
+				got2 := apply(t, set.Union, intersection, apply(t, set.Intersection, a, c))
+				if !set.Equal(got1, got2) {
+					t.Errorf("The distributive law does not hold for %v, %v and %v.", setOf(a), setOf(b), setOf(c))
+				}
+
+				// (a ∪ b) ∪ c = a ∪ (b ∪ c)
+				got1 = apply(t, set.Union, union, c)
+				got2 = apply(t, set.Union, a, apply(t, set.Union, b, c))
+				if !set.Equal(got1, got2) {
+					t.Errorf("The associative law does not hold for %v, %v and %v.", setOf(a), setOf(b), setOf(c))
+				}
+			}
+		}
+	}
+}
+
+func testCross(t *testing.T, make Maker) {
+	for _, elems1 := range samples {
+		for _, elems2 := range samples {
+			c1, c2 := make(elems1...), make(elems2...)
+			s1, s2 := setOf(c1), setOf(c2)
+
+			for _, op := range []struct {
+				name   string
+				op     operation
+				method func(s2 set.Set) (set.Set, error)
+			}{
+				{"union", set.Union, s1.Union},
+				{"intersection", set.Intersection, s1.Intersection},
+				{"difference", set.Difference, s1.Difference},
+			} {
+				want, err := op.method(s2)
+				if err != nil {
+					t.Fatalf("There was an error trying to make the %s of %v and %v.\n%v", op.name, s1, s2, err)
+				}
+
+				// The collection with a Set, and a Set with the collection.
+				if got := apply(t, op.op, c1, &s2); !got.Equal(want) {
+					t.Errorf("The %s of %v and %v is %v, instead of %v.", op.name, s1, s2, got, want)
+				}
+				if got := apply(t, op.op, &s1, c2); !got.Equal(want) {
+					t.Errorf("The %s of %v and %v is %v, instead of %v.", op.name, s1, s2, got, want)
+				}
+			}
+
+			if set.Equal(c1, &s2) != s1.Equal(s2) || set.Subset(c1, &s2) != s1.Subset(s2) {
+				t.Errorf("Equal and Subset disagree with Set for %v and %v.", s1, s2)
+			}
+		}
+	}
+}
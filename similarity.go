@@ -0,0 +1,117 @@
+package set
+
+import "math"
+
+// overlap returns the number of elements s1 and s2 have in common, without
+// building their intersection. If both are non-empty and of different types, a
+// TypeError is returned.
+func (s1 *Set) overlap(s2 Set) (int, error) {
+	if !s1.Empty() && !s2.Empty() && !s1.SameType(s2) {
+		return 0, &TypeError{s1.elementsType, s2.elementsType,
+			"The sets' types do not match."}
+	}
+
+	small, large := s1.Set, s2.Set
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+
+	n := 0
+	for elem := range small {
+		if _, ok := large[elem]; ok {
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+// Jaccard returns the Jaccard index of s1 and s2: the size of their
+// intersection divided by the size of their union. It is 1 if both sets are
+// empty. As with Union, the sets must be of the same type, unless one of them
+// is empty, otherwise a TypeError is returned. It does not allocate.
+func (s1 *Set) Jaccard(s2 Set) (float64, error) {
+	n, err := s1.overlap(s2)
+	if err != nil {
+		return 0, err
+	}
+
+	if s1.Empty() && s2.Empty() {
+		return 1, nil
+	}
+
+	return float64(n) / float64(s1.Length()+s2.Length()-n), nil
+}
+
+// SorensenDice returns the Sørensen–Dice coefficient of s1 and s2: twice the
+// size of their intersection divided by the sum of their sizes. It is 1 if
+// both sets are empty. The sets are checked as in Jaccard.
+func (s1 *Set) SorensenDice(s2 Set) (float64, error) {
+	n, err := s1.overlap(s2)
+	if err != nil {
+		return 0, err
+	}
+
+	if s1.Empty() && s2.Empty() {
+		return 1, nil
+	}
+
+	return 2 * float64(n) / float64(s1.Length()+s2.Length()), nil
+}
+
+// OverlapCoefficient returns the overlap coefficient of s1 and s2: the size of
+// their intersection divided by the size of the smaller set, so it is 1 if one
+// is a subset of the other. It is 1 if both sets are empty and 0 if only one
+// is. The sets are checked as in Jaccard.
+func (s1 *Set) OverlapCoefficient(s2 Set) (float64, error) {
+	n, err := s1.overlap(s2)
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case s1.Empty() && s2.Empty():
+		return 1, nil
+	case s1.Empty() || s2.Empty():
+		return 0, nil
+	}
+
+	smaller := s1.Length()
+	if s2.Length() < smaller {
+		smaller = s2.Length()
+	}
+
+	return float64(n) / float64(smaller), nil
+}
+
+// Cosine returns the cosine similarity of s1 and s2, seen as binary vectors:
+// the size of their intersection divided by the geometric mean of their sizes.
+// It is 1 if both sets are empty and 0 if only one is. The sets are checked as
+// in Jaccard.
+func (s1 *Set) Cosine(s2 Set) (float64, error) {
+	n, err := s1.overlap(s2)
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case s1.Empty() && s2.Empty():
+		return 1, nil
+	case s1.Empty() || s2.Empty():
+		return 0, nil
+	}
+
+	return float64(n) / math.Sqrt(float64(s1.Length())*float64(s2.Length())), nil
+}
+
+// HammingDistance returns the number of elements that are in exactly one of s1
+// and s2: the size of their symmetric difference. The sets are checked as in
+// Jaccard.
+func (s1 *Set) HammingDistance(s2 Set) (int, error) {
+	n, err := s1.overlap(s2)
+	if err != nil {
+		return 0, err
+	}
+
+	return s1.Length() + s2.Length() - 2*n, nil
+}
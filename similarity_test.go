@@ -0,0 +1,87 @@
+package set
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSimilarity(t *testing.T) {
+	s1 := CreateSet(1)
+	s1.AddAll(2, 3, 4)
+	s2 := CreateSet(3)
+	s2.AddAll(4, 5, 6, 7, 8)
+
+	tests := []struct {
+		name string
+		fn   func(s2 Set) (float64, error)
+		want float64
+	}{
+		{"Jaccard", s1.Jaccard, 2.0 / 8},
+		{"SorensenDice", s1.SorensenDice, 4.0 / 10},
+		{"OverlapCoefficient", s1.OverlapCoefficient, 2.0 / 4},
+		{"Cosine", s1.Cosine, 2 / math.Sqrt(24)},
+	}
+
+	for _, test := range tests {
+		got, err := test.fn(s2)
+		if err != nil || math.Abs(got-test.want) > 1e-12 {
+			t.Errorf("The %s similarity of %v and %v is %v, %v, instead of %v.", test.name, s1, s2, got, err, test.want)
+		}
+	}
+
+	if d, err := s1.HammingDistance(s2); err != nil || d != 6 {
+		t.Errorf("The Hamming distance of %v and %v is %v, %v.", s1, s2, d, err)
+	}
+
+	if j, _ := s1.Jaccard(s1); j != 1 {
+		t.Errorf("The Jaccard index of %v with itself is %v.", s1, j)
+	}
+}
+
+func TestSimilarityEmpty(t *testing.T) {
+	empty, other := NewSet(), NewSet()
+	s := CreateSet("a")
+
+	for name, fn := range map[string]func(s1 *Set, s2 Set) (float64, error){
+		"Jaccard":            (*Set).Jaccard,
+		"SorensenDice":       (*Set).SorensenDice,
+		"OverlapCoefficient": (*Set).OverlapCoefficient,
+		"Cosine":             (*Set).Cosine,
+	} {
+		if got, err := fn(&empty, other); err != nil || got != 1 {
+			t.Errorf("The %s similarity of two empty sets is %v, %v.", name, got, err)
+		}
+
+		if got, err := fn(&s, empty); err != nil || got != 0 {
+			t.Errorf("The %s similarity of %v and the empty set is %v, %v.", name, s, got, err)
+		}
+
+		if _, err := fn(&s, CreateSet(1)); err == nil {
+			t.Errorf("The %s similarity of sets of different types returned no error.", name)
+		}
+	}
+
+	if d, err := s.HammingDistance(empty); err != nil || d != 1 {
+		t.Errorf("The Hamming distance of %v and the empty set is %v, %v.", s, d, err)
+	}
+}
+
+func TestSimilarityAllocations(t *testing.T) {
+	s1, s2 := NewSet(), NewSet()
+	for i := 0; i < 1000; i++ {
+		s1.Add(i)
+		s2.Add(i + 500)
+	}
+
+	allocs := testing.AllocsPerRun(10, func() {
+		s1.Jaccard(s2)
+		s1.SorensenDice(s2)
+		s1.OverlapCoefficient(s2)
+		s1.Cosine(s2)
+		s1.HammingDistance(s2)
+	})
+
+	if allocs != 0 {
+		t.Errorf("The similarity metrics allocated %v times.", allocs)
+	}
+}
@@ -0,0 +1,581 @@
+package set
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+)
+
+// Comparator defines a total order on elements. It returns a negative number
+// if a comes before b, a positive number if a comes after b, and zero if they
+// are equal.
+type Comparator func(a, b interface{}) int
+
+// NaturalOrder is a Comparator for elements of any type. Booleans, integers,
+// floats and strings are ordered by value, with NaN before any other float.
+// Elements of other types are ordered by their canonical encoding, the one
+// used by Fingerprint, which is arbitrary but deterministic. Elements of
+// different types are ordered by the names of their types, with nil first;
+// distinct types with the same name are ordered arbitrarily, but consistently
+// within a process.
+func NaturalOrder(a, b interface{}) int {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+
+	switch {
+	case !va.IsValid() && !vb.IsValid():
+		return 0
+	case !va.IsValid():
+		return -1
+	case !vb.IsValid():
+		return 1
+	}
+
+	if va.Type() != vb.Type() {
+		if c := compareStrings(typeName(va.Type()), typeName(vb.Type())); c != 0 {
+			return c
+		}
+
+		// Distinct types may share a name, e.g. types declared in
+		// different functions. Their order is fixed, but only for the
+		// lifetime of the process.
+		return compareUints(uint64(reflect.ValueOf(va.Type()).Pointer()),
+			uint64(reflect.ValueOf(vb.Type()).Pointer()))
+	}
+
+	switch va.Kind() {
+	case reflect.Bool:
+		if va.Bool() == vb.Bool() {
+			return 0
+		}
+		if vb.Bool() {
+			return -1
+		}
+		return 1
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compareInts(va.Int(), vb.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return compareUints(va.Uint(), vb.Uint())
+	case reflect.Float32, reflect.Float64:
+		return compareFloats(va.Float(), vb.Float())
+	case reflect.String:
+		return compareStrings(va.String(), vb.String())
+	}
+
+	return bytes.Compare(appendValue(nil, va), appendValue(nil, vb))
+}
+
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+func compareUints(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case math.IsNaN(a) && math.IsNaN(b):
+		return 0
+	case math.IsNaN(a) || a < b:
+		return -1
+	case math.IsNaN(b) || a > b:
+		return 1
+	}
+
+	return 0
+}
+
+func compareStrings(a, b string) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+// maxLevel is the maximum height of the skip list of a SortedSet, enough for
+// 4^32 elements.
+const maxLevel = 32
+
+// skipNode is a node of an indexable skip list. span[i] is the number of nodes
+// next[i] skips over, counting the one it points to; for the last node of a
+// level it is the number of nodes after it.
+type skipNode struct {
+	elem interface{}
+	next []*skipNode
+	span []int
+}
+
+func newSkipNode(elem interface{}, level int) *skipNode {
+	return &skipNode{elem, make([]*skipNode, level), make([]int, level)}
+}
+
+// SortedSet is a set that keeps its elements ordered by a Comparator. It is
+// backed by an indexable skip list, so, apart from membership, it answers
+// order queries (Min, Max, Floor, Ceiling, Range) and rank queries (Rank, At)
+// in logarithmic time.
+type SortedSet struct {
+	head         *skipNode
+	level        int
+	length       int
+	cmp          Comparator
+	elementsType reflect.Type
+	seed         uint64 // The state of the generator of the node levels
+}
+
+// NewSortedSet allocates memory for a new SortedSet ordered by cmp. If cmp is
+// nil, NaturalOrder is used. A SortedSet created this way can have elements of
+// varying types, as long as cmp can compare them.
+func NewSortedSet(cmp Comparator) (s SortedSet) {
+	if cmp == nil {
+		cmp = NaturalOrder
+	}
+
+	s.head = newSkipNode(nil, maxLevel)
+	s.level = 1
+	s.cmp = cmp
+	s.seed = 0x9e3779b97f4a7c15
+
+	return s
+}
+
+// SetType sets the type of the elements the set accepts, with the same rules as
+// Set.SetType.
+func (s *SortedSet) SetType(elem interface{}) error {
+	newType := reflect.ValueOf(elem).Type()
+
+	if s.elementsType == nil {
+		s.elementsType = newType
+		return nil
+	}
+
+	return &TypeError{s.elementsType, newType, "Trying to re-set the set's type."}
+}
+
+// properType checks if elem is the same type as SortedSet.elementsType.
+func (s *SortedSet) properType(elem interface{}) bool {
+	return s.elementsType == nil || reflect.ValueOf(elem).Type() == s.elementsType
+}
+
+// SameType checks if the set s1 is of the same type as the set s2. If it is,
+// it returns true.
+func (s1 *SortedSet) SameType(s2 SortedSet) bool {
+	return s1.elementsType == s2.elementsType
+}
+
+// randomLevel returns the level of a new node: 1 with probability 3/4, 2 with
+// probability 3/16 and so on.
+func (s *SortedSet) randomLevel() int {
+	// xorshift64
+	s.seed ^= s.seed << 13
+	s.seed ^= s.seed >> 7
+	s.seed ^= s.seed << 17
+
+	level := 1
+	for r := s.seed; level < maxLevel && r&3 == 0; r >>= 2 {
+		level++
+	}
+
+	return level
+}
+
+// find returns, for every level, the last node whose element comes before elem,
+// and the rank of that node.
+func (s *SortedSet) find(elem interface{}) (update [maxLevel]*skipNode, rank [maxLevel]int) {
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		if i < s.level-1 {
+			rank[i] = rank[i+1]
+		}
+
+		for x.next[i] != nil && s.cmp(x.next[i].elem, elem) < 0 {
+			rank[i] += x.span[i]
+			x = x.next[i]
+		}
+
+		update[i] = x
+	}
+
+	return update, rank
+}
+
+// Add adds elem to the set s. If the element exists in the set or if the
+// element is not of the correct type, no addition is performed and false is
+// returned. Otherwise, it returns true.
+func (s *SortedSet) Add(elem interface{}) bool {
+	if !s.properType(elem) {
+		return false
+	}
+
+	update, rank := s.find(elem)
+	if next := update[0].next[0]; next != nil && s.cmp(next.elem, elem) == 0 {
+		return false
+	}
+
+	level := s.randomLevel()
+	if level > s.level {
+		for i := s.level; i < level; i++ {
+			rank[i] = 0
+			update[i] = s.head
+			update[i].span[i] = s.length
+		}
+		s.level = level
+	}
+
+	n := newSkipNode(elem, level)
+	for i := 0; i < level; i++ {
+		n.next[i] = update[i].next[i]
+		update[i].next[i] = n
+
+		n.span[i] = update[i].span[i] - (rank[0] - rank[i])
+		update[i].span[i] = rank[0] - rank[i] + 1
+	}
+
+	for i := level; i < s.level; i++ {
+		update[i].span[i]++
+	}
+
+	s.length++
+
+	return true
+}
+
+// Remove removes elem from the set s. It returns false if the element does not
+// exist in the set.
+func (s *SortedSet) Remove(elem interface{}) bool {
+	if !s.properType(elem) {
+		return false
+	}
+
+	update, _ := s.find(elem)
+	x := update[0].next[0]
+	if x == nil || s.cmp(x.elem, elem) != 0 {
+		return false
+	}
+
+	for i := 0; i < s.level; i++ {
+		if update[i].next[i] == x {
+			update[i].span[i] += x.span[i] - 1
+			update[i].next[i] = x.next[i]
+		} else {
+			update[i].span[i]--
+		}
+	}
+
+	for s.level > 1 && s.head.next[s.level-1] == nil {
+		s.level--
+	}
+
+	s.length--
+
+	return true
+}
+
+// Has returns true if the element provided already exists in the set,
+// otherwise false.
+func (s *SortedSet) Has(elem interface{}) bool {
+	if !s.properType(elem) {
+		return false
+	}
+
+	update, _ := s.find(elem)
+	x := update[0].next[0]
+
+	return x != nil && s.cmp(x.elem, elem) == 0
+}
+
+// Length returns the number of elements in the set s.
+func (s *SortedSet) Length() int {
+	return s.length
+}
+
+// Empty returns true if the set is empty, otherwise false.
+func (s *SortedSet) Empty() bool {
+	if s.Length() > 0 {
+		return false
+	}
+
+	return true
+}
+
+// Min returns the first element of the set s and true, or false if the set is
+// empty.
+func (s *SortedSet) Min() (interface{}, bool) {
+	if s.Empty() {
+		return nil, false
+	}
+
+	return s.head.next[0].elem, true
+}
+
+// Max returns the last element of the set s and true, or false if the set is
+// empty.
+func (s *SortedSet) Max() (interface{}, bool) {
+	if s.Empty() {
+		return nil, false
+	}
+
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i] != nil {
+			x = x.next[i]
+		}
+	}
+
+	return x.elem, true
+}
+
+// Floor returns the greatest element of the set s that is less than or equal
+// to elem and true, or false if there is no such element.
+func (s *SortedSet) Floor(elem interface{}) (interface{}, bool) {
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && s.cmp(x.next[i].elem, elem) <= 0 {
+			x = x.next[i]
+		}
+	}
+
+	if x == s.head {
+		return nil, false
+	}
+
+	return x.elem, true
+}
+
+// Ceiling returns the least element of the set s that is greater than or equal
+// to elem and true, or false if there is no such element.
+func (s *SortedSet) Ceiling(elem interface{}) (interface{}, bool) {
+	update, _ := s.find(elem)
+	x := update[0].next[0]
+
+	if x == nil {
+		return nil, false
+	}
+
+	return x.elem, true
+}
+
+// Rank returns the number of elements of the set s that are less than elem,
+// that is, the position elem has, or would have, in the set.
+func (s *SortedSet) Rank(elem interface{}) int {
+	_, rank := s.find(elem)
+
+	return rank[0]
+}
+
+// At returns the i-th smallest element of the set s, counting from 0. It
+// panics if i is out of range.
+func (s *SortedSet) At(i int) interface{} {
+	if i < 0 || i >= s.length {
+		panic("set: index out of range")
+	}
+
+	x := s.head
+	traversed := 0
+	for l := s.level - 1; l >= 0; l-- {
+		for x.next[l] != nil && traversed+x.span[l] <= i+1 {
+			traversed += x.span[l]
+			x = x.next[l]
+		}
+
+		if traversed == i+1 {
+			break
+		}
+	}
+
+	return x.elem
+}
+
+// ElementsType returns the type of the elements the set accepts, or nil if it
+// accepts elements of any type.
+func (s *SortedSet) ElementsType() reflect.Type {
+	return s.elementsType
+}
+
+// Each calls fn for every element of the set s, in order, until fn returns
+// false.
+func (s *SortedSet) Each(fn func(elem interface{}) bool) {
+	for x := s.head.next[0]; x != nil; x = x.next[0] {
+		if !fn(x.elem) {
+			return
+		}
+	}
+}
+
+// Range calls fn for every element of the set s between lo and hi, both
+// inclusive, in order, until fn returns false.
+func (s *SortedSet) Range(lo, hi interface{}, fn func(elem interface{}) bool) {
+	update, _ := s.find(lo)
+
+	for x := update[0].next[0]; x != nil && s.cmp(x.elem, hi) <= 0; x = x.next[0] {
+		if !fn(x.elem) {
+			return
+		}
+	}
+}
+
+// Elements returns the elements of the set s, in order.
+func (s *SortedSet) Elements() []interface{} {
+	elems := make([]interface{}, 0, s.length)
+	s.Each(func(elem interface{}) bool {
+		elems = append(elems, elem)
+		return true
+	})
+
+	return elems
+}
+
+// Set returns a Set with the elements of the set s, and the same type.
+func (s *SortedSet) Set() Set {
+	set := NewSet()
+	set.elementsType = s.elementsType
+
+	s.Each(func(elem interface{}) bool {
+		set.Add(elem)
+		return true
+	})
+
+	return set
+}
+
+// sortedBuilder appends elements to the end of an empty SortedSet in linear
+// time, remembering the last node and its rank on every level.
+type sortedBuilder struct {
+	s    *SortedSet
+	tail [maxLevel]*skipNode
+	rank [maxLevel]int
+}
+
+func newSortedBuilder(s *SortedSet) *sortedBuilder {
+	b := &sortedBuilder{s: s}
+	for i := range b.tail {
+		b.tail[i] = s.head
+	}
+
+	return b
+}
+
+// push appends elem, which must come after every element of the set.
+func (b *sortedBuilder) push(elem interface{}) {
+	s := b.s
+
+	level := s.randomLevel()
+	if level > s.level {
+		for i := s.level; i < level; i++ {
+			s.head.span[i] = s.length
+		}
+		s.level = level
+	}
+
+	n := newSkipNode(elem, level)
+	for i := 0; i < level; i++ {
+		b.tail[i].next[i] = n
+		b.tail[i].span[i] = s.length - b.rank[i] + 1
+		b.tail[i] = n
+		b.rank[i] = s.length + 1
+	}
+
+	for i := level; i < s.level; i++ {
+		b.tail[i].span[i]++
+	}
+
+	s.length++
+}
+
+// merge walks the two sets in order and returns a new set with the elements
+// that are only in s1 if left is set, those that are in both sets if both is
+// set, and those that are only in s2 if right is set. As Add would, it leaves
+// out elements of s2 that are not of the type of s1.
+func (s1 *SortedSet) merge(s2 SortedSet, left, both, right bool) SortedSet {
+	s := NewSortedSet(s1.cmp)
+	s.elementsType = s1.elementsType
+	b := newSortedBuilder(&s)
+
+	x, y := s1.head.next[0], s2.head.next[0]
+	for x != nil || y != nil {
+		var c int
+		switch {
+		case x == nil:
+			c = 1
+		case y == nil:
+			c = -1
+		default:
+			c = s1.cmp(x.elem, y.elem)
+		}
+
+		switch {
+		case c < 0:
+			if left {
+				b.push(x.elem)
+			}
+			x = x.next[0]
+		case c > 0:
+			if right && s.properType(y.elem) {
+				b.push(y.elem)
+			}
+			y = y.next[0]
+		default:
+			if both {
+				b.push(x.elem)
+			}
+			x, y = x.next[0], y.next[0]
+		}
+	}
+
+	return s
+}
+
+// Union returns the union of the two sets, ordered by the comparator of s1.
+// Both sets must be ordered the same way. It takes linear time.
+func (s1 *SortedSet) Union(s2 SortedSet) (SortedSet, error) {
+	if !s1.Empty() && !s2.Empty() {
+		if !s1.SameType(s2) {
+			return SortedSet{}, &TypeError{s1.elementsType, s2.elementsType,
+				"The sets' types do not match."}
+		}
+	}
+
+	return s1.merge(s2, true, true, true), nil
+}
+
+// Intersection returns the intersection of the two sets, ordered by the
+// comparator of s1. Both sets must be ordered the same way. It takes linear
+// time.
+func (s1 *SortedSet) Intersection(s2 SortedSet) (SortedSet, error) {
+	if !s1.Empty() && !s2.Empty() {
+		if !s1.SameType(s2) {
+			return SortedSet{}, &TypeError{s1.elementsType, s2.elementsType,
+				"The sets' types do not match."}
+		}
+	}
+
+	return s1.merge(s2, false, true, false), nil
+}
+
+// Difference returns the difference s1\s2, ordered by the comparator of s1.
+// Both sets must be ordered the same way. It takes linear time.
+func (s1 *SortedSet) Difference(s2 SortedSet) (SortedSet, error) {
+	if !s1.SameType(s2) {
+		return SortedSet{}, &TypeError{s1.elementsType, s2.elementsType,
+			"The sets' type do not match."}
+	}
+
+	return s1.merge(s2, true, false, false), nil
+}
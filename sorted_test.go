@@ -0,0 +1,171 @@
+package set
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func sortedOf(elems ...interface{}) SortedSet {
+	s := NewSortedSet(nil)
+	for _, elem := range elems {
+		s.Add(elem)
+	}
+
+	return s
+}
+
+func TestSortedSetAgainstSet(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	s := NewSortedSet(nil)
+	s.SetType(1)
+	want := CreateSet(0)
+	want.Remove(0)
+
+	for i := 0; i < 5000; i++ {
+		v := r.Intn(500)
+		if r.Intn(3) == 0 {
+			if s.Remove(v) != want.Remove(v) {
+				t.Fatalf("Remove(%d) disagrees with Set.Remove.", v)
+			}
+		} else if s.Add(v) != want.Add(v) {
+			t.Fatalf("Add(%d) disagrees with Set.Add.", v)
+		}
+	}
+
+	var elems []int
+	for v := range want.Set {
+		elems = append(elems, v.(int))
+	}
+	sort.Ints(elems)
+
+	if s.Length() != len(elems) {
+		t.Fatalf("The set has %d elements, instead of %d.", s.Length(), len(elems))
+	}
+
+	for i, v := range elems {
+		if s.At(i) != v {
+			t.Errorf("At(%d) is %v, instead of %d.", i, s.At(i), v)
+		}
+		if s.Rank(v) != i {
+			t.Errorf("Rank(%d) is %d, instead of %d.", v, s.Rank(v), i)
+		}
+		if !s.Has(v) {
+			t.Errorf("%d is not in the set.", v)
+		}
+	}
+
+	if got := s.Set(); !got.Equal(want) {
+		t.Errorf("The set does not have the same elements as %v.", want)
+	}
+}
+
+func TestSortedSetQueries(t *testing.T) {
+	s := sortedOf(10, 20, 30, 40)
+
+	if min, _ := s.Min(); min != 10 {
+		t.Errorf("The minimum of the set is %v, instead of 10.", min)
+	}
+	if max, _ := s.Max(); max != 40 {
+		t.Errorf("The maximum of the set is %v, instead of 40.", max)
+	}
+
+	if floor, ok := s.Floor(25); !ok || floor != 20 {
+		t.Errorf("The floor of 25 is %v, instead of 20.", floor)
+	}
+	if floor, ok := s.Floor(30); !ok || floor != 30 {
+		t.Errorf("The floor of 30 is %v, instead of 30.", floor)
+	}
+	if _, ok := s.Floor(5); ok {
+		t.Errorf("5 has a floor in the set.")
+	}
+
+	if ceiling, ok := s.Ceiling(25); !ok || ceiling != 30 {
+		t.Errorf("The ceiling of 25 is %v, instead of 30.", ceiling)
+	}
+	if _, ok := s.Ceiling(45); ok {
+		t.Errorf("45 has a ceiling in the set.")
+	}
+
+	var got []interface{}
+	s.Range(15, 30, func(elem interface{}) bool {
+		got = append(got, elem)
+		return true
+	})
+	if want := []interface{}{20, 30}; !reflect.DeepEqual(got, want) {
+		t.Errorf("The range [15, 30] is %v, instead of %v.", got, want)
+	}
+
+	empty := NewSortedSet(nil)
+	if _, ok := empty.Min(); ok {
+		t.Errorf("The empty set has a minimum.")
+	}
+}
+
+func TestSortedSetComparator(t *testing.T) {
+	s := NewSortedSet(func(a, b interface{}) int { return NaturalOrder(b, a) })
+	s.Add("a")
+	s.Add("c")
+	s.Add("b")
+
+	if got, want := s.Elements(), []interface{}{"c", "b", "a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("The elements of the set are %v, instead of %v.", got, want)
+	}
+
+	mixed := sortedOf("a", 2, true, 1)
+	if got, want := mixed.Elements(), []interface{}{true, 1, 2, "a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("The elements of the set are %v, instead of %v.", got, want)
+	}
+}
+
+func TestSortedSetAlgebra(t *testing.T) {
+	s1 := sortedOf(1, 3, 5, 7)
+	s2 := sortedOf(3, 4, 5, 6)
+
+	union, err := s1.Union(s2)
+	if got, want := union.Elements(), []interface{}{1, 3, 4, 5, 6, 7}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("The union of %v and %v resulted in %v, instead of %v.", s1.Elements(), s2.Elements(), got, want)
+	}
+
+	for i := 0; i < union.Length(); i++ {
+		if union.Rank(union.At(i)) != i {
+			t.Errorf("The rank of %v in the union is not %d.", union.At(i), i)
+		}
+	}
+
+	union.Add(2)
+	union.Remove(7)
+	if got, want := union.Elements(), []interface{}{1, 2, 3, 4, 5, 6}; !reflect.DeepEqual(got, want) {
+		t.Errorf("The elements of the union are %v, instead of %v.", got, want)
+	}
+
+	intersection, err := s1.Intersection(s2)
+	if got, want := intersection.Elements(), []interface{}{3, 5}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("The intersection of %v and %v resulted in %v, instead of %v.", s1.Elements(), s2.Elements(), got, want)
+	}
+
+	difference, err := s1.Difference(s2)
+	if got, want := difference.Elements(), []interface{}{1, 7}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("The difference of %v from %v resulted in %v, instead of %v.", s2.Elements(), s1.Elements(), got, want)
+	}
+
+	s3 := NewSortedSet(nil)
+	s3.SetType("a")
+	s3.Add("a")
+	s1.SetType(1)
+	if _, err := s1.Union(s3); err == nil {
+		t.Errorf("The union of %v with a set of strings succeeded.", s1.Elements())
+	}
+
+	// An empty operand skips the type check, but the union still only
+	// holds elements of the type of s1, as Add does.
+	ints := NewSortedSet(nil)
+	ints.SetType(1)
+	words := NewSortedSet(nil)
+	words.Add("a")
+	union, err = ints.Union(words)
+	if err != nil || union.Length() != 0 || len(union.Elements()) != 0 {
+		t.Errorf("The union of an empty set of ints with %v resulted in %v.", words.Elements(), union.Elements())
+	}
+}
@@ -0,0 +1,239 @@
+package set
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+)
+
+// MaxPowerSetLength is the largest set whose power set PowerSet enumerates. A
+// set of n elements has 2^n subsets, so even this many takes a long time.
+const MaxPowerSetLength = 30
+
+// ErrTooLarge is returned when a set has too many elements for the
+// combinatorial operation asked of it.
+var ErrTooLarge = errors.New("set: the set is too large to enumerate")
+
+// SubsetIterator yields subsets of a set, one at a time:
+//
+//	it := flags.Combinations(2, nil)
+//	for it.Next() {
+//		pair := it.Set()
+//		...
+//	}
+type SubsetIterator struct {
+	s     *Set
+	elems []interface{}
+	idx   []int
+	k     int
+	maxK  int
+	done  bool
+}
+
+// newSubsetIterator returns an iterator over the subsets of s with k up to
+// maxK elements, whose elements are ordered by cmp.
+func newSubsetIterator(s *Set, k, maxK int, cmp Comparator) *SubsetIterator {
+	if cmp == nil {
+		cmp = NaturalOrder
+	}
+
+	it := &SubsetIterator{
+		s:     s,
+		elems: typedElements(*s, cmp),
+		k:     k,
+		maxK:  maxK,
+	}
+	it.done = k < 0 || k > maxK || k > len(it.elems)
+
+	return it
+}
+
+// Combinations returns an iterator over the subsets of the set s with k
+// elements. The elements of s are ordered by cmp, or by NaturalOrder if cmp is
+// nil, and the subsets are yielded in lexicographic order of that ordering. If
+// k is negative or larger than the set, there are no such subsets. Elements
+// of s that are not of its type are left out, as in Product.
+func (s *Set) Combinations(k int, cmp Comparator) *SubsetIterator {
+	return newSubsetIterator(s, k, k, cmp)
+}
+
+// PowerSet returns an iterator over every subset of the set s: the empty set
+// first, then the subsets with one element, and so on, each size in the order
+// of Combinations. If s has more than MaxPowerSetLength elements, ErrTooLarge
+// is returned.
+func (s *Set) PowerSet(cmp Comparator) (*SubsetIterator, error) {
+	if s.Length() > MaxPowerSetLength {
+		return nil, ErrTooLarge
+	}
+
+	return newSubsetIterator(s, 0, s.Length(), cmp), nil
+}
+
+// Next advances the iterator to the next subset, which is then returned by Set,
+// Frozen and Elements. It returns false when there are no more subsets.
+func (it *SubsetIterator) Next() bool {
+	if it.done {
+		return false
+	}
+
+	if it.idx == nil {
+		it.first()
+		return true
+	}
+
+	n := len(it.elems)
+	i := it.k - 1
+	for i >= 0 && it.idx[i] == n-it.k+i {
+		i--
+	}
+
+	if i >= 0 {
+		it.idx[i]++
+		for j := i + 1; j < it.k; j++ {
+			it.idx[j] = it.idx[j-1] + 1
+		}
+		return true
+	}
+
+	// Every subset of this size has been yielded; move on to the next size.
+	if it.k == it.maxK || it.k == n {
+		it.done = true
+		return false
+	}
+
+	it.k++
+	it.first()
+
+	return true
+}
+
+// first sets the iterator to the first subset with it.k elements.
+func (it *SubsetIterator) first() {
+	it.idx = make([]int, it.k)
+	for i := range it.idx {
+		it.idx[i] = i
+	}
+}
+
+// Elements returns the elements of the current subset, in the order of the
+// comparator of the iterator.
+func (it *SubsetIterator) Elements() []interface{} {
+	elems := make([]interface{}, len(it.idx))
+	for i, j := range it.idx {
+		elems[i] = it.elems[j]
+	}
+
+	return elems
+}
+
+// Set returns the current subset as a new Set, with the type and the universe
+// of the original set.
+func (it *SubsetIterator) Set() Set {
+	s := NewSet()
+	s.elementsType = it.s.elementsType
+	s.domain = it.s.domain
+
+	for _, j := range it.idx {
+		s.Add(it.elems[j])
+	}
+
+	return s
+}
+
+// Frozen returns the current subset as a FrozenSet, with the type of the
+// original set.
+func (it *SubsetIterator) Frozen() FrozenSet {
+	elems := it.Elements()
+	sort.Slice(elems, func(i, j int) bool { return NaturalOrder(elems[i], elems[j]) < 0 })
+
+	return freeze(it.s.elementsType, elems)
+}
+
+// PermutationIterator yields the arrangements of distinct elements of a set,
+// one at a time, as Tuples.
+type PermutationIterator struct {
+	elems []interface{}
+	types []reflect.Type
+	idx   []int
+	used  []bool
+	done  bool
+}
+
+// Permutations returns an iterator over the k-permutations of the set s: every
+// Tuple of k distinct elements of s, each position with the type of s. The
+// elements of s are ordered by cmp, or by NaturalOrder if cmp is nil, and the
+// tuples are yielded in lexicographic order of that ordering. If k is negative
+// or larger than the set, there are no such tuples. Elements of s that are not
+// of its type are left out, as in Product.
+func (s *Set) Permutations(k int, cmp Comparator) *PermutationIterator {
+	if cmp == nil {
+		cmp = NaturalOrder
+	}
+
+	it := &PermutationIterator{elems: typedElements(*s, cmp)}
+	it.done = k < 0 || k > len(it.elems)
+
+	if !it.done {
+		it.types = make([]reflect.Type, k)
+		for i := range it.types {
+			it.types[i] = s.elementsType
+		}
+		it.used = make([]bool, len(it.elems))
+	}
+
+	return it
+}
+
+// Next advances the iterator to the next permutation, which is then returned
+// by Tuple. It returns false when there are no more permutations.
+func (it *PermutationIterator) Next() bool {
+	if it.done {
+		return false
+	}
+
+	if it.idx == nil {
+		it.idx = make([]int, len(it.types))
+		it.fill(0)
+		return true
+	}
+
+	// Advance the rightmost position that can take a larger unused element,
+	// and fill the positions after it with the smallest unused ones.
+	for i := len(it.idx) - 1; i >= 0; i-- {
+		it.used[it.idx[i]] = false
+
+		for j := it.idx[i] + 1; j < len(it.elems); j++ {
+			if !it.used[j] {
+				it.idx[i] = j
+				it.used[j] = true
+				it.fill(i + 1)
+				return true
+			}
+		}
+	}
+
+	it.done = true
+
+	return false
+}
+
+// fill sets the positions from i onwards to the smallest unused elements.
+func (it *PermutationIterator) fill(i int) {
+	for j := 0; i < len(it.idx); j++ {
+		if !it.used[j] {
+			it.idx[i] = j
+			it.used[j] = true
+			i++
+		}
+	}
+}
+
+// Tuple returns the current permutation of the iterator.
+func (it *PermutationIterator) Tuple() Tuple {
+	values := make([]interface{}, len(it.idx))
+	for i, j := range it.idx {
+		values[i] = it.elems[j]
+	}
+
+	return newTuple(it.types, values)
+}
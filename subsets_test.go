@@ -0,0 +1,132 @@
+package set
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCombinations(t *testing.T) {
+	s := CreateSet(1)
+	s.AddAll(2, 3, 4)
+
+	var got []string
+	for it := s.Combinations(2, nil); it.Next(); {
+		got = append(got, fmt.Sprint(it.Elements()))
+	}
+
+	want := "[[1 2] [1 3] [1 4] [2 3] [2 4] [3 4]]"
+	if fmt.Sprint(got) != want {
+		t.Errorf("The 2-subsets of %v are %v, instead of %v.", s, got, want)
+	}
+
+	reverse := func(a, b interface{}) int { return NaturalOrder(b, a) }
+	it := s.Combinations(3, reverse)
+	if !it.Next() || fmt.Sprint(it.Elements()) != "[4 3 2]" {
+		t.Errorf("The first 3-subset of %v in reverse is %v.", s, it.Elements())
+	}
+
+	for _, k := range []int{-1, 5} {
+		if s.Combinations(k, nil).Next() {
+			t.Errorf("The set %v has %d-subsets.", s, k)
+		}
+	}
+}
+
+func TestPowerSet(t *testing.T) {
+	s := CreateSet("a")
+	s.AddAll("b", "c", "d")
+
+	it, err := s.PowerSet(nil)
+	if err != nil {
+		t.Fatalf("The power set of %v returned %v.", s, err)
+	}
+
+	subsets := NewSet()
+	for it.Next() {
+		subset := it.Set()
+		if !subset.Subset(s) || !subset.SameType(s) {
+			t.Errorf("The set %v is not a subset of %v of the same type.", subset, s)
+		}
+		frozen := it.Frozen()
+		if back := frozen.Set(); !back.Equal(subset) {
+			t.Errorf("The frozen subset %v differs from %v.", frozen, subset)
+		}
+
+		subsets.Add(frozen)
+	}
+
+	if subsets.Length() != 16 {
+		t.Errorf("The power set of %v has %d elements, instead of 16.", s, subsets.Length())
+	}
+
+	empty := NewSet()
+	if it, _ := empty.PowerSet(nil); !it.Next() || len(it.Elements()) != 0 || it.Next() {
+		t.Errorf("The power set of the empty set is not a single empty set.")
+	}
+
+	large := NewSet()
+	for i := 0; i <= MaxPowerSetLength; i++ {
+		large.Add(i)
+	}
+
+	if _, err := large.PowerSet(nil); err != ErrTooLarge {
+		t.Errorf("The power set of a set of %d elements returned %v.", large.Length(), err)
+	}
+}
+
+func TestPermutations(t *testing.T) {
+	s := CreateSet(1)
+	s.AddAll(2, 3)
+
+	var got []string
+	for it := s.Permutations(2, nil); it.Next(); {
+		got = append(got, it.Tuple().String())
+	}
+
+	want := "[(1, 2) (1, 3) (2, 1) (2, 3) (3, 1) (3, 2)]"
+	if fmt.Sprint(got) != want {
+		t.Errorf("The 2-permutations of %v are %v, instead of %v.", s, got, want)
+	}
+
+	n := 0
+	for it := s.Permutations(3, nil); it.Next(); n++ {
+		if it.Tuple().Types()[2] != s.ElementsType() {
+			t.Errorf("The permutation %v does not have the type of %v.", it.Tuple(), s)
+		}
+	}
+
+	if n != 6 {
+		t.Errorf("The set %v has %d 3-permutations, instead of 6.", s, n)
+	}
+
+	if it := s.Permutations(0, nil); !it.Next() || it.Tuple().Len() != 0 || it.Next() {
+		t.Errorf("The 0-permutations of %v are not a single empty tuple.", s)
+	}
+
+	if s.Permutations(4, nil).Next() {
+		t.Errorf("The set %v has 4-permutations.", s)
+	}
+
+	// Elements added before the type of a set was set are left out.
+	late := NewSet()
+	late.Add("a")
+	late.SetType(1)
+	late.AddAll(2, 3)
+
+	n = 0
+	for it := late.Permutations(2, nil); it.Next(); n++ {
+	}
+	if n != 2 || late.Permutations(3, nil).Next() {
+		t.Errorf("The set %v has %d 2-permutations, instead of 2.", late, n)
+	}
+
+	n = 0
+	for it := late.Combinations(1, nil); it.Next(); n++ {
+		if f := it.Frozen(); f.Has("a") || f.Length() != 1 {
+			t.Errorf("The subset %v of %v has the wrong elements.", f, late)
+		}
+	}
+	if n != 2 {
+		t.Errorf("The set %v has %d 1-subsets, instead of 2.", late, n)
+	}
+}
@@ -0,0 +1,128 @@
+package set
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// DomainError indicates that an element does not belong to the Universe it is
+// checked against. It holds the element, as well as an error message.
+type DomainError struct {
+	Elem interface{} // The element that is not in the universe
+	Err  string
+}
+
+func (e *DomainError) Error() string {
+	e.Err = fmt.Sprintf("%v is not an element of the universe.", e.Elem)
+
+	return e.Err
+}
+
+// Universe is a finite, ordered collection of distinct values of the same type.
+// It maps every value to a position, from 0 to Size()-1, which is what allows
+// a Set of its values to be stored as a BitSet.
+type Universe struct {
+	values       []interface{}
+	index        map[interface{}]int
+	elementsType reflect.Type
+}
+
+// NewUniverse creates a Universe of the values provided, in that order.
+// Duplicate values keep their first position. All values must have the same
+// type, otherwise a TypeError is returned.
+func NewUniverse(values ...interface{}) (*Universe, error) {
+	u := &Universe{index: make(map[interface{}]int)}
+
+	for _, v := range values {
+		t := reflect.ValueOf(v).Type()
+		if u.elementsType == nil {
+			u.elementsType = t
+		} else if t != u.elementsType {
+			return nil, &TypeError{u.elementsType, t, "The universe's values do not have the same type."}
+		}
+
+		if _, ok := u.index[v]; !ok {
+			u.index[v] = len(u.values)
+			u.values = append(u.values, v)
+		}
+	}
+
+	return u, nil
+}
+
+// Size returns the number of values in the universe u.
+func (u *Universe) Size() int {
+	return len(u.values)
+}
+
+// Has returns true if v is a value of the universe u, otherwise false.
+func (u *Universe) Has(v interface{}) bool {
+	_, ok := u.index[v]
+	return ok
+}
+
+// Index returns the position of v in the universe u and true, or false if v
+// is not a value of it.
+func (u *Universe) Index(v interface{}) (int, bool) {
+	i, ok := u.index[v]
+	return i, ok
+}
+
+// Value returns the value at position i of the universe u. It panics if i is
+// out of range.
+func (u *Universe) Value(i int) interface{} {
+	return u.values[i]
+}
+
+// Set returns a Set with all the values of the universe u, with their type,
+// bound to u.
+func (u *Universe) Set() Set {
+	s := NewSet()
+	s.elementsType = u.elementsType
+	s.domain = u
+
+	for _, v := range u.values {
+		s.Add(v)
+	}
+
+	return s
+}
+
+// BitSet converts the Set s, whose elements must be values of the universe u,
+// to a BitSet over u. If s has a type other than that of u a TypeError is
+// returned, and if it has an element outside of u a DomainError.
+func (u *Universe) BitSet(s Set) (BitSet, error) {
+	if !s.Empty() && s.elementsType != nil && s.elementsType != u.elementsType {
+		return BitSet{}, &TypeError{u.elementsType, s.elementsType,
+			"The types of the set and the universe do not match."}
+	}
+
+	b := NewBitSet(u.Size())
+	for elem := range s.Set {
+		i, ok := u.index[elem]
+		if !ok {
+			return BitSet{}, &DomainError{Elem: elem}
+		}
+
+		b.Add(i)
+	}
+
+	return b, nil
+}
+
+// FromBitSet converts the BitSet b, which must be over the universe u, back to
+// a Set with the type of u.
+func (u *Universe) FromBitSet(b BitSet) (Set, error) {
+	if b.Size() != u.Size() {
+		return Set{}, ErrSizeMismatch
+	}
+
+	s := NewSet()
+	s.elementsType = u.elementsType
+
+	for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+		s.Add(u.values[i])
+	}
+
+	return s, nil
+}
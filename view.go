@@ -0,0 +1,260 @@
+package set
+
+import (
+	"reflect"
+)
+
+// UnionView, IntersectionView and DifferenceView are the results of the set
+// operations, computed lazily. They refer to their operands instead of copying
+// their elements, so they cost nothing to create and reflect any later change
+// of the operands. Has takes constant time; Length and Each walk one or both
+// operands every time they are called.
+
+// UnionView is the union of two sets, computed lazily.
+type UnionView struct {
+	s1, s2 *Set
+}
+
+// IntersectionView is the intersection of two sets, computed lazily.
+type IntersectionView struct {
+	s1, s2 *Set
+}
+
+// DifferenceView is the difference s1\s2, computed lazily.
+type DifferenceView struct {
+	s1, s2 *Set
+}
+
+// UnionView returns a view of the union of the two sets. The types of the
+// sets, and the universe of s1, are checked as in Union, when the view is
+// created and again when it is materialized. Elements later added to s2 that
+// Union would reject are left out of the view.
+func (s1 *Set) UnionView(s2 *Set) (UnionView, error) {
+	if !s1.Empty() && !s2.Empty() {
+		if !s1.SameType(*s2) {
+			return UnionView{}, &TypeError{s1.elementsType, s2.elementsType,
+				"The sets' types do not match."}
+		}
+	}
+
+	if s1.domain != nil {
+		for elem := range s2.Set {
+			if !s1.domain.Has(elem) {
+				return UnionView{}, &DomainError{Elem: elem}
+			}
+		}
+	}
+
+	return UnionView{s1, s2}, nil
+}
+
+// Has returns true if the element provided exists in either set, otherwise
+// false. As in Union, elements of s2 that s1 would not accept, because of
+// their type or its universe, are left out.
+func (v UnionView) Has(elem interface{}) bool {
+	if v.s1.Has(elem) {
+		return true
+	}
+
+	return v.s1.properType(elem) && v.s1.inDomain(elem) && v.s2.Has(elem)
+}
+
+// fromS2 returns true if elem, an element of s2, is in the union and not
+// already an element of s1.
+func (v UnionView) fromS2(elem interface{}) bool {
+	if _, ok := v.s1.Set[elem]; ok {
+		return false
+	}
+
+	return v.s1.properType(elem) && v.s1.inDomain(elem)
+}
+
+// Length returns the number of elements in the union.
+func (v UnionView) Length() int {
+	n := v.s1.Length()
+	for elem := range v.s2.Set {
+		if v.fromS2(elem) {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Empty returns true if the union is empty, otherwise false.
+func (v UnionView) Empty() bool {
+	empty := true
+	v.Each(func(interface{}) bool {
+		empty = false
+		return false
+	})
+
+	return empty
+}
+
+// Each calls fn for every element of the union until fn returns false.
+func (v UnionView) Each(fn func(elem interface{}) bool) {
+	for elem := range v.s1.Set {
+		if !fn(elem) {
+			return
+		}
+	}
+
+	for elem := range v.s2.Set {
+		if v.fromS2(elem) && !fn(elem) {
+			return
+		}
+	}
+}
+
+// ElementsType returns the type of s1, which is the type of the union.
+func (v UnionView) ElementsType() reflect.Type {
+	return v.s1.elementsType
+}
+
+// Materialize returns the union as a Set, the same one Union would return.
+func (v UnionView) Materialize() (Set, error) {
+	return v.s1.Union(*v.s2)
+}
+
+// IntersectionView returns a view of the intersection of the two sets. The
+// types of the sets are checked as in Intersection, when the view is created
+// and again when it is materialized.
+func (s1 *Set) IntersectionView(s2 *Set) (IntersectionView, error) {
+	if !s1.Empty() && !s2.Empty() {
+		if !s1.SameType(*s2) {
+			return IntersectionView{}, &TypeError{s1.elementsType, s2.elementsType,
+				"The sets' types do not match."}
+		}
+	}
+
+	return IntersectionView{s1, s2}, nil
+}
+
+// Has returns true if the element provided exists in both sets, otherwise
+// false.
+func (v IntersectionView) Has(elem interface{}) bool {
+	return v.s1.Has(elem) && v.s2.Has(elem)
+}
+
+// Length returns the number of elements in the intersection.
+func (v IntersectionView) Length() int {
+	n := 0
+	v.Each(func(interface{}) bool {
+		n++
+		return true
+	})
+
+	return n
+}
+
+// Empty returns true if the intersection is empty, otherwise false.
+func (v IntersectionView) Empty() bool {
+	empty := true
+	v.Each(func(interface{}) bool {
+		empty = false
+		return false
+	})
+
+	return empty
+}
+
+// Each calls fn for every element of the intersection until fn returns false.
+// It walks the smaller of the two sets.
+func (v IntersectionView) Each(fn func(elem interface{}) bool) {
+	small, large := v.s1, v.s2
+	if small.Length() > large.Length() {
+		small, large = large, small
+	}
+
+	for elem := range small.Set {
+		if _, ok := large.Set[elem]; ok && !fn(elem) {
+			return
+		}
+	}
+}
+
+// ElementsType returns the type of s1, which is the type of the intersection.
+func (v IntersectionView) ElementsType() reflect.Type {
+	return v.s1.elementsType
+}
+
+// Materialize returns the intersection as a Set, with the same type and
+// elements Intersection would return.
+func (v IntersectionView) Materialize() (Set, error) {
+	if _, err := v.s1.IntersectionView(v.s2); err != nil {
+		return Set{}, err
+	}
+
+	s := NewSet()
+	s.elementsType = v.s1.elementsType
+	s.domain = v.s1.domain
+
+	v.Each(func(elem interface{}) bool {
+		s.Add(elem)
+		return true
+	})
+
+	return s, nil
+}
+
+// DifferenceView returns a view of the difference s1\s2. The types of the sets
+// are checked as in Difference, when the view is created and again when it is
+// materialized.
+func (s1 *Set) DifferenceView(s2 *Set) (DifferenceView, error) {
+	if !s1.SameType(*s2) {
+		return DifferenceView{}, &TypeError{s1.elementsType, s2.elementsType,
+			"The sets' type do not match."}
+	}
+
+	return DifferenceView{s1, s2}, nil
+}
+
+// Has returns true if the element provided exists in s1 but not in s2,
+// otherwise false.
+func (v DifferenceView) Has(elem interface{}) bool {
+	return v.s1.Has(elem) && !v.s2.Has(elem)
+}
+
+// Length returns the number of elements in the difference.
+func (v DifferenceView) Length() int {
+	n := 0
+	v.Each(func(interface{}) bool {
+		n++
+		return true
+	})
+
+	return n
+}
+
+// Empty returns true if the difference is empty, otherwise false.
+func (v DifferenceView) Empty() bool {
+	empty := true
+	v.Each(func(interface{}) bool {
+		empty = false
+		return false
+	})
+
+	return empty
+}
+
+// Each calls fn for every element of the difference until fn returns false.
+func (v DifferenceView) Each(fn func(elem interface{}) bool) {
+	for elem := range v.s1.Set {
+		if !v.s2.Has(elem) && !fn(elem) {
+			return
+		}
+	}
+}
+
+// ElementsType returns the type of s1, since every element of the difference
+// is an element of s1.
+func (v DifferenceView) ElementsType() reflect.Type {
+	return v.s1.elementsType
+}
+
+// Materialize returns the difference as a Set, the same one Difference would
+// return.
+func (v DifferenceView) Materialize() (Set, error) {
+	return v.s1.Difference(*v.s2)
+}
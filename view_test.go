@@ -0,0 +1,133 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestUnionView(t *testing.T) {
+	s1 := CreateSet(1)
+	s2 := CreateSet(2)
+	s2.Add(1)
+
+	v, err := s1.UnionView(&s2)
+	if err != nil {
+		t.Fatalf("There was an error trying to make the union of %v and %v.\n%v", s1, s2, err)
+	}
+
+	if !v.Has(2) || v.Has(3) || v.Length() != 2 {
+		t.Errorf("The union of %v and %v is wrong.", s1, s2)
+	}
+
+	// The view reflects later changes of its operands.
+	s1.Add(3)
+	if !v.Has(3) || v.Length() != 3 {
+		t.Errorf("The union did not see the change of %v.", s1)
+	}
+
+	got, err := v.Materialize()
+	want, _ := s1.Union(s2)
+	if err != nil || !got.Equal(want) || !got.SameType(want) {
+		t.Errorf("The union materialized as %v, instead of %v.", got, want)
+	}
+
+	s3 := CreateSet("a")
+	if _, err := s1.UnionView(&s3); err == nil {
+		t.Errorf("The union of %v and %v succeeded.", s1, s3)
+	}
+
+	// An empty operand skips the type check, but the union still only
+	// holds elements of the type of s1, as Union does.
+	ints := NewSet()
+	ints.SetType(0)
+	words := NewSet()
+	words.Add("a")
+
+	v, err = ints.UnionView(&words)
+	if err != nil {
+		t.Fatalf("There was an error trying to make the union of %v and %v.\n%v", ints, words, err)
+	}
+
+	n := 0
+	v.Each(func(interface{}) bool { n++; return true })
+	got, _ = v.Materialize()
+	if v.Has("a") || v.Length() != 0 || !v.Empty() || n != 0 || !got.Empty() {
+		t.Errorf("The union of %v and %v holds %d elements, but materialized as %v.", ints, words, v.Length(), got)
+	}
+
+	// The universe of s1 is checked as in Union.
+	u, _ := NewUniverse(1, 2, 3)
+	bound := NewSet()
+	bound.Add(1)
+	bound.Bind(u)
+	outside := CreateSet(4)
+
+	if _, err := bound.UnionView(&outside); err == nil {
+		t.Errorf("The union of %v and %v succeeded.", bound, outside)
+	}
+
+	inside := CreateSet(2)
+	v, err = bound.UnionView(&inside)
+	if err != nil {
+		t.Fatalf("There was an error trying to make the union of %v and %v.\n%v", bound, inside, err)
+	}
+
+	inside.Add(4)
+	n = 0
+	v.Each(func(interface{}) bool { n++; return true })
+	if v.Has(4) || v.Length() != 2 || n != 2 {
+		t.Errorf("The union of %v and %v holds %d elements, outside the universe of %v.", bound, inside, v.Length(), bound)
+	}
+}
+
+func TestIntersectionView(t *testing.T) {
+	s1 := CreateSet(1)
+	s1.AddAll(2, 3)
+	s2 := CreateSet(2)
+
+	v, err := s1.IntersectionView(&s2)
+	if err != nil {
+		t.Fatalf("There was an error trying to make the intersection of %v and %v.\n%v", s1, s2, err)
+	}
+
+	if !v.Has(2) || v.Has(1) || v.Length() != 1 || v.Empty() {
+		t.Errorf("The intersection of %v and %v is wrong.", s1, s2)
+	}
+
+	s2.Add(3)
+	got, err := v.Materialize()
+	want, _ := s1.Intersection(s2)
+	if err != nil || !got.Equal(want) || !got.SameType(want) {
+		t.Errorf("The intersection materialized as %v, instead of %v.", got, want)
+	}
+
+	s2.Clear()
+	if !v.Empty() {
+		t.Errorf("The intersection of %v and %v is not empty.", s1, s2)
+	}
+}
+
+func TestDifferenceView(t *testing.T) {
+	s1 := CreateSet(1)
+	s1.AddAll(2, 3)
+	s2 := CreateSet(2)
+
+	v, err := s1.DifferenceView(&s2)
+	if err != nil {
+		t.Fatalf("There was an error trying to make the difference of %v from %v.\n%v", s2, s1, err)
+	}
+
+	if !v.Has(1) || v.Has(2) || v.Length() != 2 {
+		t.Errorf("The difference of %v from %v is wrong.", s2, s1)
+	}
+
+	s2.Add(1)
+	got, err := v.Materialize()
+	if err != nil || !got.Equal(CreateSet(3)) {
+		t.Errorf("The difference materialized as %v, instead of {3}.", got)
+	}
+
+	s3 := NewSet()
+	if _, err := s1.DifferenceView(&s3); err == nil {
+		t.Errorf("The difference of %v from %v succeeded.", s3, s1)
+	}
+}